@@ -0,0 +1,126 @@
+package boolop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// EqVec returns a BoolVector, evaluating whether each element of x is equal to the corresponding element of y (false < true)
+func EqVec(x, y *vector.BoolVector) *vector.BoolVector {
+	return cmpVec(x, y, eqByte)
+}
+
+// NeVec returns a BoolVector, evaluating whether each element of x is not equal to the corresponding element of y (false < true)
+func NeVec(x, y *vector.BoolVector) *vector.BoolVector {
+	return cmpVec(x, y, neByte)
+}
+
+// LtVec returns a BoolVector, evaluating whether each element of x is less than the corresponding element of y (false < true)
+func LtVec(x, y *vector.BoolVector) *vector.BoolVector {
+	return cmpVec(x, y, ltByte)
+}
+
+// LeVec returns a BoolVector, evaluating whether each element of x is less than or equal to the corresponding element of y (false < true)
+func LeVec(x, y *vector.BoolVector) *vector.BoolVector {
+	return cmpVec(x, y, leByte)
+}
+
+// GtVec returns a BoolVector, evaluating whether each element of x is greater than the corresponding element of y (false < true)
+func GtVec(x, y *vector.BoolVector) *vector.BoolVector {
+	return cmpVec(x, y, gtByte)
+}
+
+// GeVec returns a BoolVector, evaluating whether each element of x is greater than or equal to the corresponding element of y (false < true)
+func GeVec(x, y *vector.BoolVector) *vector.BoolVector {
+	return cmpVec(x, y, geByte)
+}
+
+// EqLit returns a BoolVector, evaluating whether each element of x is equal to a literal bool (false < true)
+func EqLit(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	return cmpVec(x, broadcastBool(x, lit), eqByte)
+}
+
+// NeLit returns a BoolVector, evaluating whether each element of x is not equal to a literal bool (false < true)
+func NeLit(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	return cmpVec(x, broadcastBool(x, lit), neByte)
+}
+
+// LtLit returns a BoolVector, evaluating whether each element of x is less than a literal bool (false < true)
+func LtLit(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	return cmpVec(x, broadcastBool(x, lit), ltByte)
+}
+
+// LeLit returns a BoolVector, evaluating whether each element of x is less than or equal to a literal bool (false < true)
+func LeLit(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	return cmpVec(x, broadcastBool(x, lit), leByte)
+}
+
+// GtLit returns a BoolVector, evaluating whether each element of x is greater than a literal bool (false < true)
+func GtLit(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	return cmpVec(x, broadcastBool(x, lit), gtByte)
+}
+
+// GeLit returns a BoolVector, evaluating whether each element of x is greater than or equal to a literal bool (false < true)
+func GeLit(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	return cmpVec(x, broadcastBool(x, lit), geByte)
+}
+
+// cmpVec performs the comparison on two BoolVectors a byte (8 elements) at a time, returning a resulting BoolVector
+func cmpVec(x, y *vector.BoolVector, opFn func(x, y byte) byte) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validBuff := make([]byte, x.Validity().Len())
+
+	xData, yData := x.Data(), y.Data()
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+
+	// chunks are divisible by 8; each worker owns whole bytes of the output bitmaps
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			// bitwise AND to get new nulls; null slots are cleared to false
+			validBuff[i] = xValid[i] & yValid[i]
+			dataBuff[i] = opFn(xData[i], yData[i]) & validBuff[i]
+		}
+	})
+
+	validity := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity)
+}
+
+// broadcastBool returns a BoolVector of the same length as x, with all elements set to a non-null literal
+func broadcastBool(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	validity := vector.NewValidityBitMap(x.Len())
+	dataBuff := make([]byte, validity.Len())
+	if lit {
+		copy(dataBuff, validity.Buffer)
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity)
+}
+
+func eqByte(x, y byte) byte {
+	return ^(x ^ y)
+}
+
+func neByte(x, y byte) byte {
+	return x ^ y
+}
+
+func ltByte(x, y byte) byte {
+	return y &^ x
+}
+
+func leByte(x, y byte) byte {
+	return ^x | y
+}
+
+func gtByte(x, y byte) byte {
+	return x &^ y
+}
+
+func geByte(x, y byte) byte {
+	return x | ^y
+}
@@ -0,0 +1,97 @@
+package boolop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// And returns the element-wise logical conjunction of two BoolVectors.
+//
+// And follows SQL (Kleene) null semantics: false AND null is false, true AND null is null.
+func And(x, y *vector.BoolVector) *vector.BoolVector {
+	return logicVec(x, y, andByte)
+}
+
+// Or returns the element-wise logical disjunction of two BoolVectors.
+//
+// Or follows SQL (Kleene) null semantics: true OR null is true, false OR null is null.
+func Or(x, y *vector.BoolVector) *vector.BoolVector {
+	return logicVec(x, y, orByte)
+}
+
+// Xor returns the element-wise exclusive disjunction of two BoolVectors; null if either element is null.
+func Xor(x, y *vector.BoolVector) *vector.BoolVector {
+	return logicVec(x, y, xorByte)
+}
+
+// AndNot returns the element-wise x AND (NOT y) of two BoolVectors, following SQL (Kleene) null semantics.
+func AndNot(x, y *vector.BoolVector) *vector.BoolVector {
+	return logicVec(x, y, andNotByte)
+}
+
+// Not returns the element-wise logical negation of a BoolVector; null elements stay null.
+func Not(x *vector.BoolVector) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validity := x.Validity().DeepCopy()
+	xData := x.Data()
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			dataBuff[i] = ^xData[i] & validity.Buffer[i]
+		}
+	})
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity)
+}
+
+// logicVec performs the logical operation on two BoolVectors a byte (8 elements) at a time.
+//
+// opFn takes the data and validity bytes of both inputs, returning the resulting data and validity bytes.
+func logicVec(x, y *vector.BoolVector, opFn func(xd, xv, yd, yv byte) (byte, byte)) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validBuff := make([]byte, x.Validity().Len())
+
+	xData, yData := x.Data(), y.Data()
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+
+	// chunks are divisible by 8; each worker owns whole bytes of the output bitmaps
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			dataBuff[i], validBuff[i] = opFn(xData[i], xValid[i], yData[i], yValid[i])
+		}
+	})
+
+	validity := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity)
+}
+
+// a result is known (not null) where it is known to be true, or known to be false;
+// data bits of null inputs are masked out, so they may hold any value
+
+func andByte(xd, xv, yd, yv byte) (byte, byte) {
+	isTrue := (xd & xv) & (yd & yv)
+	isFalse := (^xd & xv) | (^yd & yv)
+	return isTrue, isTrue | isFalse
+}
+
+func orByte(xd, xv, yd, yv byte) (byte, byte) {
+	isTrue := (xd & xv) | (yd & yv)
+	isFalse := (^xd & xv) & (^yd & yv)
+	return isTrue, isTrue | isFalse
+}
+
+func xorByte(xd, xv, yd, yv byte) (byte, byte) {
+	valid := xv & yv
+	return (xd ^ yd) & valid, valid
+}
+
+func andNotByte(xd, xv, yd, yv byte) (byte, byte) {
+	isTrue := (xd & xv) & (^yd & yv)
+	isFalse := (^xd & xv) | (yd & yv)
+	return isTrue, isTrue | isFalse
+}
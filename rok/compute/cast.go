@@ -0,0 +1,425 @@
+package compute
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/parse"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+const secsInOneDay int64 = 60 * 60 * 24
+
+// default layout for string <-> date casts
+const defaultDateLayout = "2006-01-02"
+
+// ErrCast is returned by Cast, under ErrOnFail, when a value cannot be represented in the target type
+var ErrCast = errors.New("cast failed")
+
+// CastOptions configures a Cast
+type CastOptions struct {
+	OnFail     OnFail // handling of values that cannot be represented in the target type
+	DateLayout string // time.Parse layout for string <-> date casts; defaults to "2006-01-02"
+}
+
+// Cast returns a new vector, converting each element of v to the DataType `to`.
+//
+// Supported casts are:
+//   - numeric to numeric; values out of range of `to` (or NaN/infinite floats cast to integers) fail,
+//     and floats are truncated towards zero when cast to integers
+//   - numeric to and from string; floats are formatted in their shortest form that parses back to the same
+//     value, and strings parsed as with parse.Int, parse.Uint and parse.Float
+//   - string to and from date, following opts.DateLayout
+//   - bool to and from numeric; non-zero numerics are true, and true is 1
+//   - string to bool, accepting t, f, true, false (and capitalized forms); bool to string as "true"/"false"
+//
+// Values that fail to cast are set to null, or reported as an error, depending on opts.OnFail;
+// null elements stay null.
+func Cast(v vector.Vector, to dtype.DataType, opts CastOptions) (vector.Vector, error) {
+	if opts.DateLayout == "" {
+		opts.DateLayout = defaultDateLayout
+	}
+
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[uint16]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[uint32]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[uint64]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[int8]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[int16]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[int32]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[int64]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[int]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[float32]:
+		return castFromNumeric(x, to, opts)
+	case *vector.NumericVector[float64]:
+		return castFromNumeric(x, to, opts)
+	case *vector.StringVector:
+		return castFromString(x, to, opts)
+	case *vector.DateVector:
+		return castFromDate(x, to, opts)
+	case *vector.BoolVector:
+		return castFromBool(x, to)
+	}
+	return nil, unsupportedCast(v.Type(), to)
+}
+
+func unsupportedCast(from, to dtype.DataType) error {
+	return fmt.Errorf("unsupported cast from %v to %v", from, to)
+}
+
+func castFromNumeric[F vector.Numeric](x *vector.NumericVector[F], to dtype.DataType, opts CastOptions) (vector.Vector, error) {
+	xData := x.Data()
+	switch to.Type() {
+	case dtype.UINT8:
+		return castNumeric[F, uint8](x, to, opts)
+	case dtype.UINT16:
+		return castNumeric[F, uint16](x, to, opts)
+	case dtype.UINT32:
+		return castNumeric[F, uint32](x, to, opts)
+	case dtype.UINT64:
+		return castNumeric[F, uint64](x, to, opts)
+	case dtype.INT8:
+		return castNumeric[F, int8](x, to, opts)
+	case dtype.INT16:
+		return castNumeric[F, int16](x, to, opts)
+	case dtype.INT32:
+		return castNumeric[F, int32](x, to, opts)
+	case dtype.INT64:
+		return castNumeric[F, int64](x, to, opts)
+	case dtype.FLOAT32:
+		return castNumeric[F, float32](x, to, opts)
+	case dtype.FLOAT64:
+		return castNumeric[F, float64](x, to, opts)
+
+	case dtype.STRING:
+		appendNum := numericAppender[F]()
+		return formatStrings(x.Validity(), func(buf []byte, i int) []byte {
+			return appendNum(buf, xData[i])
+		}), nil
+
+	case dtype.BOOL:
+		dataBuff := make([]byte, x.Validity().Len())
+		validity := x.Validity().DeepCopy()
+		ParallelChunks(x.Len(), 8, func(_, start, end int) {
+			for i := start; i < end; i++ {
+				if xData[i] != 0 {
+					dataBuff[i/8] |= 1 << (i % 8)
+				}
+			}
+			// null slots are cleared to false
+			for i := start / 8; i < (end+7)/8; i++ {
+				dataBuff[i] &= validity.Buffer[i]
+			}
+		})
+		return vector.BoolVecFromComponenets(to, dataBuff, validity), nil
+	}
+	return nil, unsupportedCast(x.Type(), to)
+}
+
+// castNumeric converts a NumericVector of type F to type T, checking that each value is representable
+func castNumeric[F, T vector.Numeric](x *vector.NumericVector[F], to dtype.DataType, opts CastOptions) (*vector.NumericVector[T], error) {
+	dataBuff := make([]T, x.Len())
+	validBuff := x.Validity().DeepCopyBuff()
+	xData := x.Data()
+	failures := NewFailures(opts.OnFail)
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if x.IsNull(i) {
+				continue
+			}
+			val, ok := convertNumeric[F, T](xData[i])
+			if !ok {
+				failures.Record(validBuff, i, func() error {
+					return fmt.Errorf("%w: %v value %v at index %d cannot be represented as %v", ErrCast, x.Type(), xData[i], i, to)
+				})
+				continue
+			}
+			dataBuff[i] = val
+		}
+	})
+
+	if err := failures.Err(); err != nil {
+		return nil, err
+	}
+	return vector.NumericVecFromComponents(to, dataBuff, vector.ValidityBitMapFromBuff(validBuff, x.Len())), nil
+}
+
+// convertNumeric converts x to type T, returning whether x is representable as T
+func convertNumeric[F, T vector.Numeric](x F) (T, bool) {
+	y := T(x)
+	switch {
+	case IsFloat[F]() && IsFloat[T]():
+		// NaN and infinities carry over; finite values must stay finite
+		fx := float64(x)
+		return y, math.IsNaN(fx) || math.IsInf(fx, 0) || !math.IsInf(float64(y), 0)
+	case IsFloat[F]():
+		fx := float64(x)
+		if math.IsNaN(fx) || math.IsInf(fx, 0) {
+			return 0, false
+		}
+		// out of range conversions don't round-trip
+		return y, float64(y) == math.Trunc(fx)
+	case IsFloat[T]():
+		return y, true
+	}
+	// integer to integer; must round-trip, keeping its sign
+	return y, F(y) == x && (y < 0) == (x < 0)
+}
+
+// numericAppender returns a function appending the decimal string form of a numeric of type T to a byte slice
+func numericAppender[T vector.Numeric]() func(buf []byte, x T) []byte {
+	switch {
+	case IsFloat[T]():
+		bitSize := 64
+		if _, ok := any(T(0)).(float32); ok {
+			bitSize = 32
+		}
+		return func(buf []byte, x T) []byte {
+			return strconv.AppendFloat(buf, float64(x), 'g', -1, bitSize)
+		}
+	case IsSigned[T]():
+		return func(buf []byte, x T) []byte {
+			return strconv.AppendInt(buf, int64(x), 10)
+		}
+	}
+	return func(buf []byte, x T) []byte {
+		return strconv.AppendUint(buf, uint64(x), 10)
+	}
+}
+
+func castFromString(x *vector.StringVector, to dtype.DataType, opts CastOptions) (vector.Vector, error) {
+	switch to.Type() {
+	case dtype.UINT8:
+		return castStringToNumeric[uint8](x, to, opts)
+	case dtype.UINT16:
+		return castStringToNumeric[uint16](x, to, opts)
+	case dtype.UINT32:
+		return castStringToNumeric[uint32](x, to, opts)
+	case dtype.UINT64:
+		return castStringToNumeric[uint64](x, to, opts)
+	case dtype.INT8:
+		return castStringToNumeric[int8](x, to, opts)
+	case dtype.INT16:
+		return castStringToNumeric[int16](x, to, opts)
+	case dtype.INT32:
+		return castStringToNumeric[int32](x, to, opts)
+	case dtype.INT64:
+		return castStringToNumeric[int64](x, to, opts)
+	case dtype.FLOAT32:
+		return castStringToNumeric[float32](x, to, opts)
+	case dtype.FLOAT64:
+		return castStringToNumeric[float64](x, to, opts)
+
+	case dtype.STRING:
+		return x.DeepCopy(), nil
+
+	case dtype.BOOL:
+		dataBuff := make([]byte, x.Validity().Len())
+		validBuff := x.Validity().DeepCopyBuff()
+		failures := NewFailures(opts.OnFail)
+		ParallelChunks(x.Len(), 8, func(_, start, end int) {
+			for i := start; i < end; i++ {
+				if x.IsNull(i) {
+					continue
+				}
+				val, ok := parse.Bool(x.ValAt(i))
+				if !ok {
+					failures.Record(validBuff, i, func() error { return stringCastErr(x, i, to) })
+					continue
+				}
+				if val {
+					dataBuff[i/8] |= 1 << (i % 8)
+				}
+			}
+		})
+		if err := failures.Err(); err != nil {
+			return nil, err
+		}
+		return vector.BoolVecFromComponenets(to, dataBuff, vector.ValidityBitMapFromBuff(validBuff, x.Len())), nil
+
+	case dtype.DATE:
+		dataBuff := make([]int32, x.Len())
+		validBuff := x.Validity().DeepCopyBuff()
+		failures := NewFailures(opts.OnFail)
+		ParallelChunks(x.Len(), 8, func(_, start, end int) {
+			for i := start; i < end; i++ {
+				if x.IsNull(i) {
+					continue
+				}
+				val, ok := parse.Date(x.ValAt(i), opts.DateLayout)
+				if !ok {
+					failures.Record(validBuff, i, func() error { return stringCastErr(x, i, to) })
+					continue
+				}
+				dataBuff[i] = val
+			}
+		})
+		if err := failures.Err(); err != nil {
+			return nil, err
+		}
+		return vector.DateVecFromComponents(dataBuff, vector.ValidityBitMapFromBuff(validBuff, x.Len())), nil
+	}
+	return nil, unsupportedCast(x.Type(), to)
+}
+
+// castStringToNumeric parses each string as an int64 (uint64 for unsigned types, float64 for float types),
+// before converting to type T
+func castStringToNumeric[T vector.Numeric](x *vector.StringVector, to dtype.DataType, opts CastOptions) (*vector.NumericVector[T], error) {
+	dataBuff := make([]T, x.Len())
+	validBuff := x.Validity().DeepCopyBuff()
+	failures := NewFailures(opts.OnFail)
+
+	ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if x.IsNull(i) {
+				continue
+			}
+			var val T
+			var ok bool
+			if IsFloat[T]() {
+				var f float64
+				if f, ok = parse.Float[float64](x.ValAt(i)); ok {
+					val, ok = convertNumeric[float64, T](f)
+				}
+			} else if IsSigned[T]() {
+				var n int64
+				if n, ok = parse.Int[int64](x.ValAt(i)); ok {
+					val, ok = convertNumeric[int64, T](n)
+				}
+			} else {
+				var n uint64
+				if n, ok = parse.Uint[uint64](x.ValAt(i)); ok {
+					val, ok = convertNumeric[uint64, T](n)
+				}
+			}
+			if !ok {
+				failures.Record(validBuff, i, func() error { return stringCastErr(x, i, to) })
+				continue
+			}
+			dataBuff[i] = val
+		}
+	})
+
+	if err := failures.Err(); err != nil {
+		return nil, err
+	}
+	return vector.NumericVecFromComponents(to, dataBuff, vector.ValidityBitMapFromBuff(validBuff, x.Len())), nil
+}
+
+func stringCastErr(x *vector.StringVector, i int, to dtype.DataType) error {
+	return fmt.Errorf("%w: string %q at index %d cannot be represented as %v", ErrCast, x.StringValAt(i), i, to)
+}
+
+func castFromDate(x *vector.DateVector, to dtype.DataType, opts CastOptions) (vector.Vector, error) {
+	switch to.Type() {
+	case dtype.DATE:
+		return x.DeepCopy(), nil
+	case dtype.STRING:
+		xData := x.Data()
+		return formatStrings(x.Validity(), func(buf []byte, i int) []byte {
+			return time.Unix(int64(xData[i])*secsInOneDay, 0).UTC().AppendFormat(buf, opts.DateLayout)
+		}), nil
+	}
+	return nil, unsupportedCast(x.Type(), to)
+}
+
+func castFromBool(x *vector.BoolVector, to dtype.DataType) (vector.Vector, error) {
+	switch to.Type() {
+	case dtype.UINT8:
+		return castBoolToNumeric[uint8](x, to), nil
+	case dtype.UINT16:
+		return castBoolToNumeric[uint16](x, to), nil
+	case dtype.UINT32:
+		return castBoolToNumeric[uint32](x, to), nil
+	case dtype.UINT64:
+		return castBoolToNumeric[uint64](x, to), nil
+	case dtype.INT8:
+		return castBoolToNumeric[int8](x, to), nil
+	case dtype.INT16:
+		return castBoolToNumeric[int16](x, to), nil
+	case dtype.INT32:
+		return castBoolToNumeric[int32](x, to), nil
+	case dtype.INT64:
+		return castBoolToNumeric[int64](x, to), nil
+	case dtype.FLOAT32:
+		return castBoolToNumeric[float32](x, to), nil
+	case dtype.FLOAT64:
+		return castBoolToNumeric[float64](x, to), nil
+	case dtype.BOOL:
+		return x.DeepCopy(), nil
+	case dtype.STRING:
+		return formatStrings(x.Validity(), func(buf []byte, i int) []byte {
+			return strconv.AppendBool(buf, x.ValAt(i))
+		}), nil
+	}
+	return nil, unsupportedCast(x.Type(), to)
+}
+
+func castBoolToNumeric[T vector.Numeric](x *vector.BoolVector, to dtype.DataType) *vector.NumericVector[T] {
+	dataBuff := make([]T, x.Len())
+	ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if x.ValAt(i) {
+				dataBuff[i] = 1
+			}
+		}
+	})
+	return vector.NumericVecFromComponents(to, dataBuff, x.Validity().DeepCopy())
+}
+
+// formatStrings builds a StringVector in parallel, appending the bytes of each non-null element i with appendFn.
+//
+// Each worker formats its chunk into its own buffer; the buffers are then joined, and offsets rebased.
+func formatStrings(validity vector.ValidityBitMap, appendFn func(buf []byte, i int) []byte) *vector.StringVector {
+	n := validity.TrueLen
+	offsetsBuff := make([]int64, n+1)
+	chunkData := make([][]byte, NumWorkers)
+
+	ParallelChunks(n, 8, func(w, start, end int) {
+		var buf []byte
+		for i := start; i < end; i++ {
+			offsetsBuff[i] = int64(len(buf))
+			if !validity.IsNull(i) {
+				buf = appendFn(buf, i)
+			}
+		}
+		chunkData[w] = buf
+	})
+
+	// starting byte of each chunk
+	chunkStart := make([]int64, NumWorkers)
+	var totalLenB int64
+	for w, buf := range chunkData {
+		chunkStart[w] = totalLenB
+		totalLenB += int64(len(buf))
+	}
+	dataBuff := make([]byte, totalLenB)
+
+	ParallelChunks(n, 8, func(w, start, end int) {
+		copy(dataBuff[chunkStart[w]:], chunkData[w])
+		for i := start; i < end; i++ {
+			offsetsBuff[i] += chunkStart[w]
+		}
+	})
+
+	// handle final offset element
+	offsetsBuff[n] = totalLenB
+
+	return vector.StringVecFromComponents(dataBuff, offsetsBuff, validity.DeepCopy())
+}
@@ -0,0 +1,173 @@
+package compute
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+func allValid(n int) []bool {
+	valid := make([]bool, n)
+	for i := range valid {
+		valid[i] = true
+	}
+	return valid
+}
+
+func mustCast(t *testing.T, v vector.Vector, to dtype.DataType, opts CastOptions) vector.Vector {
+	t.Helper()
+	res, err := Cast(v, to, opts)
+	if err != nil {
+		t.Fatalf("Cast to %v: %v", to, err)
+	}
+	if res.Type().Type() != to.Type() {
+		t.Fatalf("Cast to %v gave type %v", to, res.Type())
+	}
+	return res
+}
+
+func TestCastFloatStringRoundTrip(t *testing.T) {
+	vals := []float64{0, 0.3, -1e-07, 1e21, 1e-300, 5e-324, math.MaxFloat64, -math.MaxFloat64,
+		math.Pi, 123456789.125, math.Inf(1), math.Inf(-1), math.NaN(), math.Copysign(0, -1)}
+	x := vector.NumericVecFromComponents(dtype.Float64{}, vals, vector.NewValidityBitMap(len(vals)))
+
+	strs := mustCast(t, x, dtype.String{}, CastOptions{OnFail: ErrOnFail}).(*vector.StringVector)
+	back := mustCast(t, strs, dtype.Float64{}, CastOptions{OnFail: ErrOnFail}).(*vector.NumericVector[float64])
+	for i, want := range vals {
+		got := back.Data()[i]
+		if back.IsNull(i) || math.Float64bits(got) != math.Float64bits(want) && !(math.IsNaN(got) && math.IsNaN(want)) {
+			t.Errorf("float64 %v -> %q -> %v (null: %v)", want, strs.StringValAt(i), got, back.IsNull(i))
+		}
+	}
+
+	vals32 := []float32{0.1, 3.4028235e38, 1e-45, -7.5, 16777217}
+	x32 := vector.NumericVecFromComponents(dtype.Float32{}, vals32, vector.NewValidityBitMap(len(vals32)))
+	strs = mustCast(t, x32, dtype.String{}, CastOptions{}).(*vector.StringVector)
+	back32 := mustCast(t, strs, dtype.Float32{}, CastOptions{OnFail: ErrOnFail}).(*vector.NumericVector[float32])
+	for i, want := range vals32 {
+		if got := back32.Data()[i]; got != want {
+			t.Errorf("float32 %v -> %q -> %v", want, strs.StringValAt(i), got)
+		}
+	}
+}
+
+func TestCastIntegerStringRoundTrip(t *testing.T) {
+	ints := []int64{0, -1, 42, math.MaxInt64, math.MinInt64}
+	xi := vector.NumericVecFromComponents(dtype.Int64{}, ints, vector.NewValidityBitMap(len(ints)))
+	strs := mustCast(t, xi, dtype.String{}, CastOptions{})
+	backI := mustCast(t, strs, dtype.Int64{}, CastOptions{OnFail: ErrOnFail}).(*vector.NumericVector[int64])
+	for i, want := range ints {
+		if got := backI.Data()[i]; got != want {
+			t.Errorf("int64 %d -> %d", want, got)
+		}
+	}
+
+	uints := []uint64{0, 1 << 63, math.MaxUint64}
+	xu := vector.NumericVecFromComponents(dtype.UInt64{}, uints, vector.NewValidityBitMap(len(uints)))
+	strs = mustCast(t, xu, dtype.String{}, CastOptions{})
+	backU := mustCast(t, strs, dtype.UInt64{}, CastOptions{OnFail: ErrOnFail}).(*vector.NumericVector[uint64])
+	for i, want := range uints {
+		if got := backU.Data()[i]; got != want {
+			t.Errorf("uint64 %d -> %d", want, got)
+		}
+	}
+}
+
+func TestCastStringToNumeric(t *testing.T) {
+	tests := []struct {
+		in   string
+		to   dtype.DataType
+		want float64 // compared after conversion to float64
+		ok   bool
+	}{
+		{"255", dtype.UInt8{}, 255, true},
+		{"256", dtype.UInt8{}, 0, false},
+		{"-1", dtype.UInt32{}, 0, false},
+		{"18446744073709551615", dtype.UInt64{}, math.MaxUint64, true},
+		{"-128", dtype.Int8{}, -128, true},
+		{"128", dtype.Int8{}, 0, false},
+		{"1.5", dtype.Int64{}, 0, false},
+		{"1e5", dtype.Float64{}, 1e5, true},
+		{"1e39", dtype.Float32{}, 0, false},
+		{"-", dtype.Float64{}, 0, false},
+		{".", dtype.Float64{}, 0, false},
+		{"", dtype.Int32{}, 0, false},
+	}
+	for _, tt := range tests {
+		x := vector.StringVecFromStrings([]string{tt.in}, []bool{true})
+
+		res := mustCast(t, x, tt.to, CastOptions{OnFail: NullOnFail})
+		if res.IsNull(0) == tt.ok {
+			t.Errorf("Cast(%q, %v): null = %v, want %v", tt.in, tt.to, res.IsNull(0), !tt.ok)
+			continue
+		}
+		if tt.ok {
+			asFloat := mustCast(t, res, dtype.Float64{}, CastOptions{}).(*vector.NumericVector[float64])
+			if got := asFloat.Data()[0]; got != tt.want {
+				t.Errorf("Cast(%q, %v) = %v, want %v", tt.in, tt.to, got, tt.want)
+			}
+		}
+
+		_, err := Cast(x, tt.to, CastOptions{OnFail: ErrOnFail})
+		if tt.ok != (err == nil) || (err != nil && !errors.Is(err, ErrCast)) {
+			t.Errorf("Cast(%q, %v) with ErrOnFail: err = %v", tt.in, tt.to, err)
+		}
+	}
+}
+
+func TestCastNumericToNumeric(t *testing.T) {
+	vals := []float64{1.9, -1.9, 300, math.NaN(), math.Inf(1), -129}
+	x := vector.NumericVecFromComponents(dtype.Float64{}, vals, vector.NewValidityBitMap(len(vals)))
+	res := mustCast(t, x, dtype.Int8{}, CastOptions{}).(*vector.NumericVector[int8])
+	want := []int8{1, -1, 0, 0, 0, 0}
+	wantNull := []bool{false, false, true, true, true, true}
+	for i := range vals {
+		if res.IsNull(i) != wantNull[i] || (!wantNull[i] && res.Data()[i] != want[i]) {
+			t.Errorf("float64 %v -> int8 %d (null: %v)", vals[i], res.Data()[i], res.IsNull(i))
+		}
+	}
+	if res.NullCount() != 4 {
+		t.Errorf("NullCount = %d, want 4", res.NullCount())
+	}
+}
+
+func TestCastDateAndBool(t *testing.T) {
+	days := []int32{0, -1, 11016, 2932896}
+	x := vector.DateVecFromComponents(days, vector.NewValidityBitMap(len(days)))
+	strs := mustCast(t, x, dtype.String{}, CastOptions{}).(*vector.StringVector)
+	wantStrs := []string{"1970-01-01", "1969-12-31", "2000-02-29", "9999-12-31"}
+	for i, want := range wantStrs {
+		if got := strs.StringValAt(i); got != want {
+			t.Errorf("date %d -> %q, want %q", days[i], got, want)
+		}
+	}
+	back := mustCast(t, strs, dtype.Date{}, CastOptions{OnFail: ErrOnFail}).(*vector.DateVector)
+	for i, want := range days {
+		if got := back.Data()[i]; got != want {
+			t.Errorf("date %d -> %q -> %d", want, strs.StringValAt(i), got)
+		}
+	}
+
+	b := vector.StringVecFromStrings([]string{"true", "F", "maybe", ""}, []bool{true, true, true, false})
+	bools := mustCast(t, b, dtype.Bool{}, CastOptions{}).(*vector.BoolVector)
+	if !bools.ValAt(0) || bools.ValAt(1) || !bools.IsNull(2) || !bools.IsNull(3) || bools.NullCount() != 2 {
+		t.Errorf("string -> bool gave %v %v, nulls %v %v", bools.ValAt(0), bools.ValAt(1), bools.IsNull(2), bools.IsNull(3))
+	}
+	strs = mustCast(t, bools, dtype.String{}, CastOptions{}).(*vector.StringVector)
+	if strs.StringValAt(0) != "true" || strs.StringValAt(1) != "false" || !strs.IsNull(2) {
+		t.Errorf("bool -> string gave %q %q", strs.StringValAt(0), strs.StringValAt(1))
+	}
+}
+
+func TestCastKeepsNulls(t *testing.T) {
+	x := vector.NumericVecFromComponents(dtype.Int32{}, []int32{1, 2, 3}, vector.ValidityBitMapFromBools([]bool{true, false, true}))
+	for _, to := range []dtype.DataType{dtype.Int64{}, dtype.Float32{}, dtype.String{}, dtype.Bool{}} {
+		res := mustCast(t, x, to, CastOptions{OnFail: ErrOnFail})
+		if !res.IsNull(1) || res.IsNull(0) || res.NullCount() != 1 {
+			t.Errorf("Cast to %v: nulls not kept", to)
+		}
+	}
+}
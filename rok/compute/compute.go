@@ -2,8 +2,81 @@ package compute
 
 import (
 	"runtime"
+	"sync"
 )
 
 // NumWorkers defines the number of worker goroutines at any given moment. This value
 // defaults to `runtime.NumCPU()`.
 var NumWorkers int = runtime.NumCPU()
+
+// ChunkBounds returns the [start, end) bounds of worker i's chunk, when splitting n elements
+// across NumWorkers workers. Chunk sizes are made divisible by align, so that chunks never share
+// a byte of a bitmap when align is 8; the final chunk takes any remainder.
+func ChunkBounds(i, n, align int) (int, int) {
+	chunkSize := n / (NumWorkers * align) * align
+	start, end := i*chunkSize, i*chunkSize+chunkSize
+	if i == NumWorkers-1 {
+		end = n
+	}
+	return start, end
+}
+
+// ParallelChunks splits n elements into NumWorkers chunks (see ChunkBounds), and runs fn on each
+// chunk in its own goroutine, returning once all chunks are done.
+func ParallelChunks(n, align int, fn func(worker, start, end int)) {
+	var wg sync.WaitGroup
+	wg.Add(NumWorkers)
+	for i := 0; i < NumWorkers; i++ {
+		go func(i int) {
+			defer wg.Done()
+
+			start, end := ChunkBounds(i, n, align)
+			fn(i, start, end)
+		}(i)
+	}
+	wg.Wait()
+}
+
+// OnFail determines how a kernel handles an element whose operation fails, such as
+// on integer overflow, integer division by zero, or a failed cast.
+type OnFail int
+
+const (
+	NullOnFail OnFail = iota // failed elements are set to null
+	ErrOnFail                // the kernel returns an error, reporting the first failed element
+)
+
+// Failures tracks elements whose operation failed, across the workers of a kernel
+type Failures struct {
+	onFail   OnFail
+	mu       sync.Mutex
+	firstIdx int // lowest failed index, or -1
+	firstErr error
+}
+
+// NewFailures returns a new Failures, handling failed elements according to onFail
+func NewFailures(onFail OnFail) *Failures {
+	return &Failures{onFail: onFail, firstIdx: -1}
+}
+
+// Record handles a failed element at index i: with NullOnFail, the element is set to null in validBuff,
+// and with ErrOnFail, the error from mkErr is kept if i is the lowest failed index so far.
+//
+// The validity bitmap byte holding i must be owned by the calling worker.
+func (f *Failures) Record(validBuff []byte, i int, mkErr func() error) {
+	if f.onFail == NullOnFail {
+		validBuff[i/8] &^= 1 << (i % 8)
+		return
+	}
+	// keep the lowest failed index, so the reported error doesn't depend on scheduling
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.firstIdx == -1 || i < f.firstIdx {
+		f.firstIdx, f.firstErr = i, mkErr()
+	}
+}
+
+// Err returns the error of the lowest failed index, or nil if no element failed under ErrOnFail
+func (f *Failures) Err() error {
+	return f.firstErr
+}
@@ -0,0 +1,29 @@
+package dateop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Min returns the earliest non-null date in x (as days since Unix epoch); returns false if x has no non-null elements
+func Min(x *vector.DateVector) (int32, bool) {
+	return numop.Min(daysView(x))
+}
+
+// Max returns the latest non-null date in x (as days since Unix epoch); returns false if x has no non-null elements
+func Max(x *vector.DateVector) (int32, bool) {
+	return numop.Max(daysView(x))
+}
+
+// GroupMin returns the earliest non-null date of x in each group; a group with no non-null elements is null
+func GroupMin(x *vector.DateVector, g *compute.Groups) *vector.DateVector {
+	res := numop.GroupMin(daysView(x), g)
+	return vector.DateVecFromComponents(res.Data(), res.Validity())
+}
+
+// GroupMax returns the latest non-null date of x in each group; a group with no non-null elements is null
+func GroupMax(x *vector.DateVector, g *compute.Groups) *vector.DateVector {
+	res := numop.GroupMax(daysView(x), g)
+	return vector.DateVecFromComponents(res.Data(), res.Validity())
+}
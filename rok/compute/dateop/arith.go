@@ -0,0 +1,67 @@
+package dateop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// TruncUnit represents a calendar unit that dates are truncated to
+type TruncUnit int
+
+const (
+	TruncWeek    TruncUnit = iota // weeks beginning on Monday
+	TruncMonth                    // calendar months
+	TruncQuarter                  // calendar quarters
+	TruncYear                     // calendar years
+)
+
+// AddDays returns each date in x, shifted by n days
+func AddDays(x *vector.DateVector, n int32) *vector.DateVector {
+	return mapDays(x, func(d int32) int32 { return d + n })
+}
+
+// AddMonths returns each date in x, shifted by n months; days past the end of the resulting month are
+// clamped to its last day, so that 2024-01-31 plus one month is 2024-02-29
+func AddMonths(x *vector.DateVector, n int32) *vector.DateVector {
+	return mapDays(x, func(d int32) int32 {
+		year, month, day := civilFromDays(d)
+		months := int64(year)*12 + int64(month-1) + int64(n)
+		year, month = int32(floorDiv(months, 12)), int32(floorMod(months, 12))+1
+		return daysFromCivil(year, month, min(day, daysInMonth(year, month)))
+	})
+}
+
+// DiffDays returns the number of days from each date in y to the corresponding date in x (x - y), as an int32 vector
+func DiffDays(x, y *vector.DateVector) *vector.NumericVector[int32] {
+	return numop.SubVec(daysView(x), daysView(y))
+}
+
+// Truncate returns each date in x, truncated to the first day of its week, month, quarter or year
+func Truncate(x *vector.DateVector, unit TruncUnit) *vector.DateVector {
+	return mapDays(x, func(d int32) int32 {
+		if unit == TruncWeek {
+			return d - isoWeekday(d) + 1
+		}
+		year, month, _ := civilFromDays(d)
+		switch unit {
+		case TruncQuarter:
+			month = (month-1)/3*3 + 1
+		case TruncYear:
+			month = 1
+		}
+		return daysFromCivil(year, month, 1)
+	})
+}
+
+// mapDays applies fn to each date in x, returning a DateVector; null elements stay null
+func mapDays(x *vector.DateVector, fn func(d int32) int32) *vector.DateVector {
+	dataBuff := make([]int32, x.Len())
+	data := x.Data()
+	compute.ParallelChunks(x.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			dataBuff[i] = fn(data[i])
+		}
+	})
+	return vector.DateVecFromComponents(dataBuff, x.Validity().DeepCopy())
+}
@@ -0,0 +1,98 @@
+package dateop
+
+import "time"
+
+// Civil (proleptic Gregorian) calendar algorithms on days since Unix epoch, following Howard Hinnant's
+// "chrono-Compatible Low-Level Date Algorithms"; eras are 400-year cycles starting on March 1st, so that
+// leap days fall at the end of each year of an era.
+
+const (
+	daysPerEra   = 146097 // days in a 400-year cycle
+	epochShift   = 719468 // days from 0000-03-01 to 1970-01-01
+	epochWeekday = 4      // 1970-01-01 was a Thursday
+)
+
+// civilFromDays returns the year, month [1, 12] and day [1, 31] of a date
+func civilFromDays(days int32) (year, month, day int32) {
+	z := int64(days) + epochShift
+	era := floorDiv(z, daysPerEra)
+	doe := z - era*daysPerEra                              // [0, 146096]
+	yoe := (doe - doe/1460 + doe/36524 - doe/146096) / 365 // [0, 399]
+	doy := doe - (365*yoe + yoe/4 - yoe/100)               // [0, 365], from March 1st
+	mp := (5*doy + 2) / 153                                // [0, 11], from March
+	day = int32(doy - (153*mp+2)/5 + 1)
+	month = int32(mp + 3)
+	if mp >= 10 {
+		month = int32(mp - 9)
+	}
+	year = int32(yoe + era*400)
+	if month <= 2 {
+		year++
+	}
+	return year, month, day
+}
+
+// daysFromCivil returns the date of a year, month [1, 12] and day [1, 31]
+func daysFromCivil(year, month, day int32) int32 {
+	y := int64(year)
+	if month <= 2 {
+		y--
+	}
+	era := floorDiv(y, 400)
+	yoe := y - era*400 // [0, 399]
+	mp := int64(month) + 9
+	if month > 2 {
+		mp = int64(month) - 3
+	}
+	doy := (153*mp+2)/5 + int64(day) - 1   // [0, 365]
+	doe := yoe*365 + yoe/4 - yoe/100 + doy // [0, 146096]
+	return int32(era*daysPerEra + doe - epochShift)
+}
+
+// DaysFromTime returns the date of t, in days since Unix epoch; the calendar date of t in its location,
+// whatever its time of day
+func DaysFromTime(t time.Time) int32 {
+	year, month, day := t.Date()
+	return daysFromCivil(int32(year), int32(month), int32(day))
+}
+
+// isLeapYear returns whether year has a February 29th
+func isLeapYear(year int32) bool {
+	return year%4 == 0 && (year%100 != 0 || year%400 == 0)
+}
+
+// daysInMonth returns the number of days in a month of a year
+func daysInMonth(year, month int32) int32 {
+	switch month {
+	case 2:
+		if isLeapYear(year) {
+			return 29
+		}
+		return 28
+	case 4, 6, 9, 11:
+		return 30
+	}
+	return 31
+}
+
+// weekday returns the day of the week of a date, from Sunday (0) to Saturday (6), as with time.Weekday
+func weekday(days int32) int32 {
+	return int32(floorMod(int64(days)+epochWeekday, 7))
+}
+
+// isoWeekday returns the day of the week of a date, from Monday (1) to Sunday (7)
+func isoWeekday(days int32) int32 {
+	return int32(floorMod(int64(days)+epochWeekday-1, 7)) + 1
+}
+
+func floorDiv(x, y int64) int64 {
+	q := x / y
+	if (x%y != 0) && ((x < 0) != (y < 0)) {
+		q--
+	}
+	return q
+}
+
+func floorMod(x, y int64) int64 {
+	return x - floorDiv(x, y)*y
+}
@@ -0,0 +1,96 @@
+package dateop
+
+import (
+	"testing"
+	"time"
+)
+
+// timeDays returns the days since Unix epoch of a time.Time
+func timeDays(t time.Time) int32 {
+	return int32(t.Unix() / (24 * 60 * 60))
+}
+
+func TestCivil(t *testing.T) {
+	tests := []struct {
+		days             int32
+		year, month, day int32
+	}{
+		{0, 1970, 1, 1},
+		{-1, 1969, 12, 31},
+		{59, 1970, 3, 1},
+		{10956, 1999, 12, 31},
+		{11016, 2000, 2, 29},
+		{11017, 2000, 3, 1},
+		{-25508, 1900, 3, 1},
+		{-25509, 1900, 2, 28},
+		{-719162, 1, 1, 1},
+		{-719163, 0, 12, 31},
+		{-719468, 0, 3, 1},
+		{-719469, 0, 2, 29},
+		{-719528, 0, 1, 1},
+		{-719529, -1, 12, 31},
+		{2932896, 9999, 12, 31},
+	}
+	for _, tt := range tests {
+		if y, m, d := civilFromDays(tt.days); y != tt.year || m != tt.month || d != tt.day {
+			t.Errorf("civilFromDays(%d) = %d-%d-%d; want %d-%d-%d", tt.days, y, m, d, tt.year, tt.month, tt.day)
+		}
+		if got := daysFromCivil(tt.year, tt.month, tt.day); got != tt.days {
+			t.Errorf("daysFromCivil(%d, %d, %d) = %d; want %d", tt.year, tt.month, tt.day, got, tt.days)
+		}
+	}
+}
+
+func TestCivilMatchesTime(t *testing.T) {
+	// every day from year -200 to 4200, covering leap centuries either side of the epoch and year 0
+	start := time.Date(-200, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(4200, time.December, 31, 0, 0, 0, 0, time.UTC)
+	for days := timeDays(start); days <= timeDays(end); days++ {
+		tm := time.Unix(int64(days)*24*60*60, 0).UTC()
+		year, month, day := civilFromDays(days)
+		if int(year) != tm.Year() || time.Month(month) != tm.Month() || int(day) != tm.Day() {
+			t.Fatalf("civilFromDays(%d) = %d-%d-%d; want %v", days, year, month, day, tm.Format(time.DateOnly))
+		}
+		if got := daysFromCivil(year, month, day); got != days {
+			t.Fatalf("daysFromCivil(%d, %d, %d) = %d; want %d", year, month, day, got, days)
+		}
+		if got := weekday(days); time.Weekday(got) != tm.Weekday() {
+			t.Fatalf("weekday(%d) = %d; want %v", days, got, tm.Weekday())
+		}
+		if got := daysInMonth(year, month); int(got) != time.Date(tm.Year(), tm.Month()+1, 0, 0, 0, 0, 0, time.UTC).Day() {
+			t.Fatalf("daysInMonth(%d, %d) = %d", year, month, got)
+		}
+		if _, week := tm.ISOWeek(); int(isoWeek(days)) != week {
+			t.Fatalf("isoWeek(%d) = %d; want %d (%v)", days, isoWeek(days), week, tm.Format(time.DateOnly))
+		}
+	}
+}
+
+func TestISOWeek(t *testing.T) {
+	tests := []struct {
+		date string
+		want int32
+	}{
+		{"2004-12-26", 52}, // Sunday, before a 53-week year's end
+		{"2004-12-27", 53},
+		{"2005-01-02", 53}, // belongs to the previous ISO year
+		{"2005-01-03", 1},
+		{"2007-12-31", 1}, // belongs to the next ISO year
+		{"2008-12-29", 1},
+		{"2009-12-31", 53},
+		{"2010-01-03", 53},
+		{"2010-01-04", 1},
+		{"1970-01-01", 1},
+		{"1969-12-29", 1},
+		{"1969-12-28", 52},
+	}
+	for _, tt := range tests {
+		tm, err := time.Parse(time.DateOnly, tt.date)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := isoWeek(timeDays(tm)); got != tt.want {
+			t.Errorf("isoWeek(%s) = %d; want %d", tt.date, got, tt.want)
+		}
+	}
+}
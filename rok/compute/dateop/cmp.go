@@ -0,0 +1,72 @@
+package dateop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// EqVec returns a BoolVector, evaluating whether each date in x is equal to the corresponding date in y
+func EqVec(x, y *vector.DateVector) *vector.BoolVector {
+	return numop.EqVec(daysView(x), daysView(y))
+}
+
+// NeVec returns a BoolVector, evaluating whether each date in x is not equal to the corresponding date in y
+func NeVec(x, y *vector.DateVector) *vector.BoolVector {
+	return numop.NeVec(daysView(x), daysView(y))
+}
+
+// LtVec returns a BoolVector, evaluating whether each date in x is earlier than the corresponding date in y
+func LtVec(x, y *vector.DateVector) *vector.BoolVector {
+	return numop.LtVec(daysView(x), daysView(y))
+}
+
+// LeVec returns a BoolVector, evaluating whether each date in x is earlier than or equal to the corresponding date in y
+func LeVec(x, y *vector.DateVector) *vector.BoolVector {
+	return numop.LeVec(daysView(x), daysView(y))
+}
+
+// GtVec returns a BoolVector, evaluating whether each date in x is later than the corresponding date in y
+func GtVec(x, y *vector.DateVector) *vector.BoolVector {
+	return numop.GtVec(daysView(x), daysView(y))
+}
+
+// GeVec returns a BoolVector, evaluating whether each date in x is later than or equal to the corresponding date in y
+func GeVec(x, y *vector.DateVector) *vector.BoolVector {
+	return numop.GeVec(daysView(x), daysView(y))
+}
+
+// EqLit returns a BoolVector, evaluating whether each date in x is equal to a literal date (as days since Unix epoch)
+func EqLit(x *vector.DateVector, lit int32) *vector.BoolVector {
+	return numop.EqLit(daysView(x), lit)
+}
+
+// NeLit returns a BoolVector, evaluating whether each date in x is not equal to a literal date (as days since Unix epoch)
+func NeLit(x *vector.DateVector, lit int32) *vector.BoolVector {
+	return numop.NeLit(daysView(x), lit)
+}
+
+// LtLit returns a BoolVector, evaluating whether each date in x is earlier than a literal date (as days since Unix epoch)
+func LtLit(x *vector.DateVector, lit int32) *vector.BoolVector {
+	return numop.LtLit(daysView(x), lit)
+}
+
+// LeLit returns a BoolVector, evaluating whether each date in x is earlier than or equal to a literal date (as days since Unix epoch)
+func LeLit(x *vector.DateVector, lit int32) *vector.BoolVector {
+	return numop.LeLit(daysView(x), lit)
+}
+
+// GtLit returns a BoolVector, evaluating whether each date in x is later than a literal date (as days since Unix epoch)
+func GtLit(x *vector.DateVector, lit int32) *vector.BoolVector {
+	return numop.GtLit(daysView(x), lit)
+}
+
+// GeLit returns a BoolVector, evaluating whether each date in x is later than or equal to a literal date (as days since Unix epoch)
+func GeLit(x *vector.DateVector, lit int32) *vector.BoolVector {
+	return numop.GeLit(daysView(x), lit)
+}
+
+// daysView returns a NumericVector sharing the underlying day counts and validity of a DateVector; no data is copied
+func daysView(x *vector.DateVector) *vector.NumericVector[int32] {
+	return vector.NumericVecFromComponents(dtype.Int32{}, x.Data(), x.Validity())
+}
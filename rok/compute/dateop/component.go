@@ -0,0 +1,79 @@
+package dateop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Year returns the year of each date in x, as an int32 vector
+func Year(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, func(d int32) int32 {
+		year, _, _ := civilFromDays(d)
+		return year
+	})
+}
+
+// Month returns the month of each date in x, from January (1) to December (12), as an int32 vector
+func Month(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, func(d int32) int32 {
+		_, month, _ := civilFromDays(d)
+		return month
+	})
+}
+
+// Day returns the day of the month of each date in x, as an int32 vector
+func Day(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, func(d int32) int32 {
+		_, _, day := civilFromDays(d)
+		return day
+	})
+}
+
+// Weekday returns the day of the week of each date in x, from Sunday (0) to Saturday (6) as with
+// time.Weekday, as an int32 vector
+func Weekday(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, weekday)
+}
+
+// DayOfYear returns the day of the year of each date in x, from 1 to 366, as an int32 vector
+func DayOfYear(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, func(d int32) int32 {
+		year, _, _ := civilFromDays(d)
+		return d - daysFromCivil(year, 1, 1) + 1
+	})
+}
+
+// Quarter returns the quarter of the year of each date in x, from 1 to 4, as an int32 vector
+func Quarter(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, func(d int32) int32 {
+		_, month, _ := civilFromDays(d)
+		return (month + 2) / 3
+	})
+}
+
+// ISOWeek returns the ISO 8601 week number of each date in x, from 1 to 53, as an int32 vector; weeks
+// begin on Monday, and week 1 of a year is the week holding its first Thursday
+func ISOWeek(x *vector.DateVector) *vector.NumericVector[int32] {
+	return extract(x, isoWeek)
+}
+
+// isoWeek returns the ISO 8601 week number of a date
+func isoWeek(d int32) int32 {
+	// the week's Thursday falls in the week's ISO year
+	thursday := d - isoWeekday(d) + 4
+	year, _, _ := civilFromDays(thursday)
+	return (thursday-daysFromCivil(year, 1, 1))/7 + 1
+}
+
+// extract applies fn to each date in x, returning an int32 vector; null elements stay null
+func extract(x *vector.DateVector, fn func(d int32) int32) *vector.NumericVector[int32] {
+	dataBuff := make([]int32, x.Len())
+	data := x.Data()
+	compute.ParallelChunks(x.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			dataBuff[i] = fn(data[i])
+		}
+	})
+	return vector.NumericVecFromComponents(dtype.Int32{}, dataBuff, x.Validity().DeepCopy())
+}
@@ -0,0 +1,249 @@
+package dateop
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// layoutElem represents an element of a date layout
+type layoutElem int
+
+const (
+	elemLiteral      layoutElem = iota
+	elemLongYear                // "2006"
+	elemYear                    // "06"
+	elemLongMonth               // "January"
+	elemMonth                   // "Jan"
+	elemZeroMonth               // "01"
+	elemNumMonth                // "1"
+	elemLongDay                 // "Monday"
+	elemDay                     // "Mon"
+	elemZeroYearDay             // "002"
+	elemZeroDay                 // "02"
+	elemUnderDay                // "_2"
+	elemNumDay                  // "2"
+	elemUnderYearDay            // "__2"
+)
+
+// midnight holds the time elements of Go's reference layout, formatted at midnight UTC, the time of
+// every date; longer elements are matched before their prefixes
+var midnight = []struct {
+	std string
+	lit string
+}{
+	{"15", "00"}, {"03", "12"}, {"3", "12"}, {"04", "00"}, {"4", "0"}, {"05", "00"}, {"5", "0"},
+	{"PM", "AM"}, {"pm", "am"}, {"MST", "UTC"},
+	{"-070000", "+000000"}, {"-07:00:00", "+00:00:00"}, {"-0700", "+0000"}, {"-07:00", "+00:00"}, {"-07", "+00"},
+	{"Z070000", "Z"}, {"Z07:00:00", "Z"}, {"Z0700", "Z"}, {"Z07:00", "Z"}, {"Z07", "Z"},
+}
+
+var longMonthNames = [...]string{"January", "February", "March", "April", "May", "June", "July",
+	"August", "September", "October", "November", "December"}
+
+var longDayNames = [...]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"}
+
+type layoutToken struct {
+	elem layoutElem
+	lit  string
+}
+
+// Format formats each date in x following layout, a Go time layout (see time.Layout), e.g. "2006-01-02" or
+// "Mon, 02 Jan 2006"; null elements stay null. Time elements are formatted as midnight UTC, as with
+// time.Format, e.g. "2006-01-02 15:04" gives "2024-03-15 00:00".
+func Format(x *vector.DateVector, layout string) *vector.StringVector {
+	tokens := parseLayout(layout)
+	data := x.Data()
+
+	n := x.Len()
+	newOffsetsBuffer := make([]int64, n+1)
+	chunkData := make([][]byte, compute.NumWorkers)
+
+	compute.ParallelChunks(n, 1, func(w, start, end int) {
+		var buf []byte
+		for i := start; i < end; i++ {
+			newOffsetsBuffer[i] = int64(len(buf))
+			if !x.IsNull(i) {
+				buf = appendDate(buf, data[i], tokens)
+			}
+		}
+		chunkData[w] = buf
+	})
+
+	// starting byte of each chunk
+	chunkStart := make([]int64, compute.NumWorkers)
+	var totalLenB int64
+	for w, buf := range chunkData {
+		chunkStart[w] = totalLenB
+		totalLenB += int64(len(buf))
+	}
+	newDataBuffer := make([]byte, totalLenB)
+
+	compute.ParallelChunks(n, 1, func(w, start, end int) {
+		copy(newDataBuffer[chunkStart[w]:], chunkData[w])
+		for i := start; i < end; i++ {
+			newOffsetsBuffer[i] += chunkStart[w]
+		}
+	})
+
+	// handle final offset element
+	newOffsetsBuffer[n] = totalLenB
+
+	return vector.StringVecFromComponents(newDataBuffer, newOffsetsBuffer, x.Validity().DeepCopy())
+}
+
+// FormatDate formats a date, in days since the Unix epoch, following layout (see Format)
+func FormatDate(d int32, layout string) string {
+	return string(appendDate(nil, d, parseLayout(layout)))
+}
+
+// parseLayout splits a layout into date elements and literal text; time elements become literals
+func parseLayout(layout string) []layoutToken {
+	var tokens []layoutToken
+	lit := 0
+	for i := 0; i < len(layout); {
+		tok, n := nextElem(layout[i:])
+		if n == 0 {
+			i++
+			continue
+		}
+		if lit < i {
+			tokens = append(tokens, layoutToken{elem: elemLiteral, lit: layout[lit:i]})
+		}
+		tokens = append(tokens, tok)
+		i += n
+		lit = i
+	}
+	if lit < len(layout) {
+		tokens = append(tokens, layoutToken{elem: elemLiteral, lit: layout[lit:]})
+	}
+	return tokens
+}
+
+// nextElem returns the layout element at the start of layout, and its length; 0 if layout does not start
+// with an element. Elements are matched as with time.Format, e.g. "Jan" is only a month if not followed
+// by a lower-case letter.
+func nextElem(layout string) (layoutToken, int) {
+	for _, m := range midnight {
+		if strings.HasPrefix(layout, m.std) {
+			return layoutToken{elem: elemLiteral, lit: m.lit}, len(m.std)
+		}
+	}
+	date := func(elem layoutElem, n int) (layoutToken, int) {
+		return layoutToken{elem: elem}, n
+	}
+	switch {
+	case strings.HasPrefix(layout, "January"):
+		return date(elemLongMonth, 7)
+	case strings.HasPrefix(layout, "Jan") && !startsWithLower(layout[3:]):
+		return date(elemMonth, 3)
+	case strings.HasPrefix(layout, "Monday"):
+		return date(elemLongDay, 6)
+	case strings.HasPrefix(layout, "Mon") && !startsWithLower(layout[3:]):
+		return date(elemDay, 3)
+	case strings.HasPrefix(layout, "2006"):
+		return date(elemLongYear, 4)
+	case strings.HasPrefix(layout, "01"):
+		return date(elemZeroMonth, 2)
+	case strings.HasPrefix(layout, "02"):
+		return date(elemZeroDay, 2)
+	case strings.HasPrefix(layout, "06"):
+		return date(elemYear, 2)
+	case strings.HasPrefix(layout, "002"):
+		return date(elemZeroYearDay, 3)
+	case strings.HasPrefix(layout, "_2006"):
+		// a literal _, followed by a year
+		return layoutToken{}, 0
+	case strings.HasPrefix(layout, "_2"):
+		return date(elemUnderDay, 2)
+	case strings.HasPrefix(layout, "__2"):
+		return date(elemUnderYearDay, 3)
+	case strings.HasPrefix(layout, "1"):
+		return date(elemNumMonth, 1)
+	case strings.HasPrefix(layout, "2"):
+		return date(elemNumDay, 1)
+	}
+
+	// fractional seconds, a run of 0s or 9s after a '.' or ',', not followed by a digit; at midnight,
+	// 0s stay 0s, and 9s are dropped along with their separator
+	if len(layout) < 2 || (layout[0] != '.' && layout[0] != ',') || (layout[1] != '0' && layout[1] != '9') {
+		return layoutToken{}, 0
+	}
+	j := 1
+	for j < len(layout) && layout[j] == layout[1] {
+		j++
+	}
+	if j < len(layout) && '0' <= layout[j] && layout[j] <= '9' {
+		return layoutToken{}, 0
+	}
+	if layout[1] == '9' {
+		return layoutToken{elem: elemLiteral}, j
+	}
+	return layoutToken{elem: elemLiteral, lit: layout[:j]}, j
+}
+
+// startsWithLower returns whether s starts with a lower-case ASCII letter
+func startsWithLower(s string) bool {
+	return len(s) > 0 && 'a' <= s[0] && s[0] <= 'z'
+}
+
+// appendDate appends a date, formatted following tokens, to buf
+func appendDate(buf []byte, d int32, tokens []layoutToken) []byte {
+	year, month, day := civilFromDays(d)
+	for _, t := range tokens {
+		switch t.elem {
+		case elemLiteral:
+			buf = append(buf, t.lit...)
+		case elemLongYear:
+			// as with time.Format, at least 4 digits after any sign
+			if year < 0 {
+				buf = append(buf, '-')
+			}
+			buf = appendPadded(buf, max(year, -year), 4, '0')
+		case elemYear:
+			// as with time.Format, the last two digits of the year's absolute value
+			buf = appendPadded(buf, max(year, -year)%100, 2, '0')
+		case elemLongMonth:
+			buf = append(buf, longMonthNames[month-1]...)
+		case elemMonth:
+			buf = append(buf, longMonthNames[month-1][:3]...)
+		case elemZeroMonth:
+			buf = appendPadded(buf, month, 2, '0')
+		case elemNumMonth:
+			buf = strconv.AppendInt(buf, int64(month), 10)
+		case elemLongDay:
+			buf = append(buf, longDayNames[weekday(d)]...)
+		case elemDay:
+			buf = append(buf, longDayNames[weekday(d)][:3]...)
+		case elemZeroYearDay:
+			buf = appendPadded(buf, d-daysFromCivil(year, 1, 1)+1, 3, '0')
+		case elemZeroDay:
+			buf = appendPadded(buf, day, 2, '0')
+		case elemUnderDay:
+			buf = appendPadded(buf, day, 2, ' ')
+		case elemUnderYearDay:
+			buf = appendPadded(buf, d-daysFromCivil(year, 1, 1)+1, 3, ' ')
+		case elemNumDay:
+			buf = strconv.AppendInt(buf, int64(day), 10)
+		}
+	}
+	return buf
+}
+
+// appendPadded appends a non-negative integer to buf, left-padded with pad to width bytes
+func appendPadded(buf []byte, v int32, width int, pad byte) []byte {
+	for w := digits(v); w < width; w++ {
+		buf = append(buf, pad)
+	}
+	return strconv.AppendInt(buf, int64(v), 10)
+}
+
+func digits(v int32) int {
+	n := 1
+	for ; v >= 10; v /= 10 {
+		n++
+	}
+	return n
+}
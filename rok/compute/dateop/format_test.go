@@ -0,0 +1,48 @@
+package dateop
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatDate(t *testing.T) {
+	tests := []struct {
+		days   int32
+		layout string
+		want   string
+	}{
+		{19797, "2006-01-02", "2024-03-15"},
+		{19797, "2006-01-02 15:04", "2024-03-15 00:00"},
+		{19797, "3:04PM", "12:00AM"},
+		{19797, "Jan _2 15:04:05.000", "Mar 15 00:00:00.000"},
+		{19797, "2006-01-02T15:04:05.999Z07:00", "2024-03-15T00:00:00Z"},
+		{19797, "Monday, 02-Jan-06 15:04:05 MST", "Friday, 15-Mar-24 00:00:00 UTC"},
+		{19797, "02 Jan 06 15:04 -0700", "15 Mar 24 00:00 +0000"},
+		{19797, "Janet Month", "Janet Month"},
+		{19797, "_2006 002 __2", "_2024 075  75"},
+		{-1, "2006-01-02 15:04:05", "1969-12-31 00:00:00"},
+	}
+	for _, tt := range tests {
+		if got := FormatDate(tt.days, tt.layout); got != tt.want {
+			t.Errorf("FormatDate(%d, %q) = %q; want %q", tt.days, tt.layout, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDateMatchesTime(t *testing.T) {
+	layouts := []string{
+		time.Layout, time.ANSIC, time.UnixDate, time.RubyDate, time.RFC822, time.RFC822Z, time.RFC850,
+		time.RFC1123, time.RFC1123Z, time.RFC3339, time.RFC3339Nano, time.Kitchen, time.Stamp,
+		time.StampMilli, time.StampMicro, time.StampNano, time.DateTime, time.DateOnly, time.TimeOnly,
+		"2006-01-02T15:04:05.000000-07:00:00", "Z070000 Z07 -070000 -07", "pm 3 4 5 002 __2 _2 1 2 06",
+		"Mon Monday Mond January Janu", "1.5,9,000 .99x .0001",
+	}
+	for _, layout := range layouts {
+		for days := int32(-800000); days <= 800000; days += 997 {
+			want := time.Unix(int64(days)*24*60*60, 0).UTC().Format(layout)
+			if got := FormatDate(days, layout); got != want {
+				t.Fatalf("FormatDate(%d, %q) = %q; want %q", days, layout, got, want)
+			}
+		}
+	}
+}
@@ -0,0 +1,94 @@
+package compute
+
+import (
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Groups partitions the rows of one or more vectors into groups, such as the groups of a GroupBy.
+//
+// The row indices of each group are stored contiguously, in increasing row order unless built with
+// NewGroupsInOrder.
+type Groups struct {
+	Rows    []int // row indices, ordered by group
+	Offsets []int // group g spans Rows[Offsets[g]:Offsets[g+1]]
+}
+
+// NewGroups returns the Groups given the group id of each row, where ids are in [0, nGroups)
+func NewGroups(ids []int, nGroups int) *Groups {
+	return NewGroupsInOrder(ids, nGroups, nil)
+}
+
+// NewGroupsInOrder returns the Groups given the group id of each row (see NewGroups), with the rows of
+// each group following their order in `order`, a permutation of the rows; a nil order is row order
+func NewGroupsInOrder(ids []int, nGroups int, order []int) *Groups {
+	// counting sort of rows by group id
+	offsets := make([]int, nGroups+1)
+	for _, id := range ids {
+		offsets[id+1]++
+	}
+	for g := 0; g < nGroups; g++ {
+		offsets[g+1] += offsets[g]
+	}
+
+	rows := make([]int, len(ids))
+	next := make([]int, nGroups)
+	copy(next, offsets[:nGroups])
+	for j := range ids {
+		i := j
+		if order != nil {
+			i = order[j]
+		}
+		rows[next[ids[i]]] = i
+		next[ids[i]]++
+	}
+	return &Groups{Rows: rows, Offsets: offsets}
+}
+
+// Len returns the number of groups
+func (g *Groups) Len() int {
+	return len(g.Offsets) - 1
+}
+
+// GroupRows returns the row indices of group i
+func (g *Groups) GroupRows(i int) []int {
+	return g.Rows[g.Offsets[i]:g.Offsets[i+1]]
+}
+
+// GroupCount returns the number of non-null elements of v in each group, as an int64 vector
+func GroupCount(v vector.Vector, g *Groups) *vector.NumericVector[int64] {
+	return groupCount(v, g, false)
+}
+
+// GroupCountNull returns the number of null elements of v in each group, as an int64 vector
+func GroupCountNull(v vector.Vector, g *Groups) *vector.NumericVector[int64] {
+	return groupCount(v, g, true)
+}
+
+func groupCount(v vector.Vector, g *Groups, countNull bool) *vector.NumericVector[int64] {
+	dataBuff := make([]int64, g.Len())
+	validity := v.Validity()
+
+	ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			rows := g.GroupRows(i)
+			if v.NullCount() == 0 {
+				if !countNull {
+					dataBuff[i] = int64(len(rows))
+				}
+				continue
+			}
+			var nulls int64
+			for _, r := range rows {
+				nulls += int64(validity.IsNullBinary(r))
+			}
+			if countNull {
+				dataBuff[i] = nulls
+			} else {
+				dataBuff[i] = int64(len(rows)) - nulls
+			}
+		}
+	})
+
+	return vector.NumericVecFromComponents(dtype.Int64{}, dataBuff, vector.NewValidityBitMap(g.Len()))
+}
@@ -0,0 +1,128 @@
+package hashop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// GroupIDs assigns a group id to each row of the key vectors, such that rows with equal keys (see RowsEqual)
+// share a group id; null keys form their own group.
+//
+// Group ids are numbered in order of each group's first row, which GroupIDs also returns.
+//
+// Each worker groups its own chunk of rows into a partial hash table; the partial tables are then merged.
+func GroupIDs(keys []vector.Vector) (ids []int, firstRows []int, err error) {
+	ids, t, err := groupIDs(keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	return ids, t.rows, nil
+}
+
+// groupIDs assigns group ids to each row of the key vectors (see GroupIDs), returning the table of groups
+func groupIDs(keys []vector.Vector) ([]int, *table, error) {
+	hashes, err := HashRows(keys)
+	if err != nil {
+		return nil, nil, err
+	}
+	eq, err := RowsEqual(keys, keys)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// each worker's partial groups; ids are worker-local until remapped
+	ids := make([]int, len(hashes))
+	locals := make([]*table, compute.NumWorkers)
+	compute.ParallelChunks(len(hashes), 1, func(w, start, end int) {
+		t := newTable(0)
+		for i := start; i < end; i++ {
+			ids[i] = t.findOrInsert(hashes[i], i, eq)
+		}
+		locals[w] = t
+	})
+
+	// merge partial groups in worker order, so that groups stay ordered by first row
+	global := newTable(locals[0].len())
+	remaps := make([][]int, compute.NumWorkers)
+	for w, t := range locals {
+		remaps[w] = make([]int, t.len())
+		for local, row := range t.rows {
+			remaps[w][local] = global.findOrInsert(t.hashes[local], row, eq)
+		}
+	}
+
+	compute.ParallelChunks(len(hashes), 1, func(w, start, end int) {
+		for i := start; i < end; i++ {
+			ids[i] = remaps[w][ids[i]]
+		}
+	})
+	return ids, global, nil
+}
+
+// table is an open-addressing hash table of groups, each identified by a representative row
+type table struct {
+	slots  []int    // group id + 1 held by each slot; 0 if empty
+	hashes []uint64 // hash of each group
+	rows   []int    // representative row of each group
+	mask   uint64
+}
+
+func newTable(sizeHint int) *table {
+	size := 64
+	for size < 2*sizeHint {
+		size <<= 1
+	}
+	return &table{slots: make([]int, size), mask: uint64(size - 1)}
+}
+
+// len returns the number of groups in the table
+func (t *table) len() int {
+	return len(t.rows)
+}
+
+// find returns the id of the group with hash h whose representative row r satisfies eq(r, row), or -1 if none does
+func (t *table) find(h uint64, row int, eq func(i, j int) bool) int {
+	for s := h & t.mask; ; s = (s + 1) & t.mask {
+		id := t.slots[s] - 1
+		if id == -1 {
+			return -1
+		}
+		if t.hashes[id] == h && eq(t.rows[id], row) {
+			return id
+		}
+	}
+}
+
+// findOrInsert returns the id of the group with hash h whose representative row r satisfies eq(r, row);
+// if none does, a new group is added with `row` as its representative
+func (t *table) findOrInsert(h uint64, row int, eq func(i, j int) bool) int {
+	// keep the load factor at most 1/2
+	if 2*(len(t.rows)+1) > len(t.slots) {
+		t.grow()
+	}
+	for s := h & t.mask; ; s = (s + 1) & t.mask {
+		id := t.slots[s] - 1
+		if id == -1 {
+			t.slots[s] = len(t.rows) + 1
+			t.hashes = append(t.hashes, h)
+			t.rows = append(t.rows, row)
+			return len(t.rows) - 1
+		}
+		if t.hashes[id] == h && eq(t.rows[id], row) {
+			return id
+		}
+	}
+}
+
+// grow doubles the number of slots, re-inserting each group
+func (t *table) grow() {
+	t.slots = make([]int, 2*len(t.slots))
+	t.mask = uint64(len(t.slots) - 1)
+	for id, h := range t.hashes {
+		s := h & t.mask
+		for t.slots[s] != 0 {
+			s = (s + 1) & t.mask
+		}
+		t.slots[s] = id + 1
+	}
+}
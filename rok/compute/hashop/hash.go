@@ -0,0 +1,286 @@
+package hashop
+
+import (
+	"fmt"
+	"hash/maphash"
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// seed for hashing string bytes; fixed for the lifetime of the process
+var seed = maphash.MakeSeed()
+
+const (
+	nullKey   uint64 = 0x9e3779b97f4a7c15 // key of a null element
+	nanKey    uint64 = 0x7ff8000000000001 // key of any float NaN
+	rowPrime  uint64 = 0x100000001b3      // multiplier combining the keys of successive columns
+	emptyHash uint64 = 0xcbf29ce484222325 // starting hash of every row
+)
+
+// HashRows returns a hash of each row of the key vectors, which must all be of the same length.
+//
+// Rows with equal keys hash equally (see RowsEqual): all nulls hash equally, and float keys
+// hash -0 equal to 0, and all NaN values equally.
+func HashRows(keys []vector.Vector) ([]uint64, error) {
+	hashers := make([]hasher, len(keys))
+	for i, k := range keys {
+		h, err := newHasher(k)
+		if err != nil {
+			return nil, err
+		}
+		hashers[i] = h
+	}
+	n, err := keysLen(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	hashes := make([]uint64, n)
+	compute.ParallelChunks(n, 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			hashes[i] = emptyHash
+		}
+		for _, h := range hashers {
+			h(hashes, start, end)
+		}
+		for i := start; i < end; i++ {
+			hashes[i] = mix64(hashes[i])
+		}
+	})
+	return hashes, nil
+}
+
+// RowsEqual returns a function evaluating whether row i of the x keys is equal to row j of the y keys;
+// x and y must hold the same number of vectors, of pairwise identical types.
+//
+// A null key is equal only to another null; float NaN keys are equal to each other.
+func RowsEqual(x, y []vector.Vector) (func(i, j int) bool, error) {
+	if len(x) != len(y) {
+		return nil, fmt.Errorf("mismatched number of keys %d and %d", len(x), len(y))
+	}
+	eqs := make([]func(i, j int) bool, len(x))
+	for k := range x {
+		eq, err := newEqual(x[k], y[k])
+		if err != nil {
+			return nil, err
+		}
+		eqs[k] = eq
+	}
+	if len(eqs) == 1 {
+		return eqs[0], nil
+	}
+	return func(i, j int) bool {
+		for _, eq := range eqs {
+			if !eq(i, j) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+// keysLen returns the common length of the key vectors
+func keysLen(keys []vector.Vector) (int, error) {
+	if len(keys) == 0 {
+		return 0, fmt.Errorf("at least one key is required")
+	}
+	n := keys[0].Len()
+	for _, k := range keys[1:] {
+		if k.Len() != n {
+			return 0, fmt.Errorf("mismatched key lengths %d and %d", n, k.Len())
+		}
+	}
+	return n, nil
+}
+
+// hasher combines the key of each row in [start, end) of a single vector into hashes
+type hasher func(hashes []uint64, start, end int)
+
+func newHasher(v vector.Vector) (hasher, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[uint16]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[uint32]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[uint64]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[int8]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[int16]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[int32]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[int64]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[int]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[float32]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.NumericVector[float64]:
+		return fixedHasher(x.Data(), x.Validity()), nil
+	case *vector.DateVector:
+		return fixedHasher(x.Data(), x.Validity()), nil
+
+	case *vector.StringVector:
+		// hashes the bytes between offsets in place; no Go strings are allocated
+		return func(hashes []uint64, start, end int) {
+			for i := start; i < end; i++ {
+				k := nullKey
+				if !x.IsNull(i) {
+					k = maphash.Bytes(seed, x.ValAt(i))
+				}
+				hashes[i] = combine(hashes[i], k)
+			}
+		}, nil
+
+	case *vector.BoolVector:
+		return func(hashes []uint64, start, end int) {
+			for i := start; i < end; i++ {
+				k := nullKey
+				if !x.IsNull(i) {
+					k = uint64(x.Data()[i/8]>>(i%8)) & 1
+				}
+				hashes[i] = combine(hashes[i], k)
+			}
+		}, nil
+	}
+	return nil, fmt.Errorf("hashing not supported for vector of type %v", v.Type())
+}
+
+func fixedHasher[T vector.Numeric](data []T, validity vector.ValidityBitMap) hasher {
+	float := compute.IsFloat[T]()
+	return func(hashes []uint64, start, end int) {
+		for i := start; i < end; i++ {
+			var k uint64
+			switch {
+			case validity.IsNull(i):
+				k = nullKey
+			case float:
+				k = floatKey(float64(data[i]))
+			default:
+				k = uint64(data[i])
+			}
+			hashes[i] = combine(hashes[i], k)
+		}
+	}
+}
+
+// floatKey returns the bits of a float, such that -0 and 0, and all NaN values, share a key
+func floatKey(f float64) uint64 {
+	switch {
+	case f == 0:
+		return 0
+	case f != f:
+		return nanKey
+	}
+	return math.Float64bits(f)
+}
+
+func combine(h, k uint64) uint64 {
+	return h*rowPrime ^ mix64(k)
+}
+
+// mix64 is the 64-bit finalizer of MurmurHash3
+func mix64(x uint64) uint64 {
+	x ^= x >> 33
+	x *= 0xff51afd7ed558ccd
+	x ^= x >> 33
+	x *= 0xc4ceb9fe1a85ec53
+	x ^= x >> 33
+	return x
+}
+
+func newEqual(x, y vector.Vector) (func(i, j int) bool, error) {
+	if x.Type().Type() != y.Type().Type() {
+		return nil, fmt.Errorf("mismatched key types %v and %v", x.Type(), y.Type())
+	}
+	switch xv := x.(type) {
+	case *vector.NumericVector[uint8]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[uint8]))
+	case *vector.NumericVector[uint16]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[uint16]))
+	case *vector.NumericVector[uint32]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[uint32]))
+	case *vector.NumericVector[uint64]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[uint64]))
+	case *vector.NumericVector[int8]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[int8]))
+	case *vector.NumericVector[int16]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[int16]))
+	case *vector.NumericVector[int32]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[int32]))
+	case *vector.NumericVector[int64]:
+		// int and int64 vectors share the Int64 DataType
+		if yv, ok := y.(*vector.NumericVector[int]); ok {
+			return fixedEqual(xv.Data(), xv.Validity(), toInt64s(yv))
+		}
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[int64]))
+	case *vector.NumericVector[int]:
+		if yv, ok := y.(*vector.NumericVector[int64]); ok {
+			return fixedEqual(toInt64s(xv).Data(), xv.Validity(), yv)
+		}
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[int]))
+	case *vector.NumericVector[float32]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[float32]))
+	case *vector.NumericVector[float64]:
+		return fixedEqual(xv.Data(), xv.Validity(), y.(*vector.NumericVector[float64]))
+
+	case *vector.DateVector:
+		yv := y.(*vector.DateVector)
+		xd, yd := xv.Data(), yv.Data()
+		return func(i, j int) bool {
+			xn, yn := xv.IsNull(i), yv.IsNull(j)
+			if xn || yn {
+				return xn && yn
+			}
+			return xd[i] == yd[j]
+		}, nil
+
+	case *vector.StringVector:
+		yv := y.(*vector.StringVector)
+		return func(i, j int) bool {
+			xn, yn := xv.IsNull(i), yv.IsNull(j)
+			if xn || yn {
+				return xn && yn
+			}
+			return string(xv.ValAt(i)) == string(yv.ValAt(j))
+		}, nil
+
+	case *vector.BoolVector:
+		yv := y.(*vector.BoolVector)
+		return func(i, j int) bool {
+			xn, yn := xv.IsNull(i), yv.IsNull(j)
+			if xn || yn {
+				return xn && yn
+			}
+			return xv.ValAt(i) == yv.ValAt(j)
+		}, nil
+	}
+	return nil, fmt.Errorf("equality not supported for vector of type %v", x.Type())
+}
+
+func fixedEqual[T vector.Numeric](xd []T, xValid vector.ValidityBitMap, y *vector.NumericVector[T]) (func(i, j int) bool, error) {
+	yd, yValid := y.Data(), y.Validity()
+	return func(i, j int) bool {
+		xn, yn := xValid.IsNull(i), yValid.IsNull(j)
+		if xn || yn {
+			return xn && yn
+		}
+		a, b := xd[i], yd[j]
+		// only NaN is not equal to itself
+		return a == b || (a != a && b != b)
+	}, nil
+}
+
+// toInt64s returns an int64 vector holding the elements of an int vector
+func toInt64s(x *vector.NumericVector[int]) *vector.NumericVector[int64] {
+	data := make([]int64, x.Len())
+	for i, v := range x.Data() {
+		data[i] = int64(v)
+	}
+	return vector.NumericVecFromComponents(x.Type(), data, x.Validity())
+}
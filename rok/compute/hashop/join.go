@@ -0,0 +1,49 @@
+package hashop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Matches hashes the build key vectors, and probes them with the probe key vectors; both must hold
+// the same number of vectors, of pairwise identical types.
+//
+// Matches returns the group id of each build row (see GroupIDs) and the number of groups, and for each
+// probe row, the id of the build group with an equal key, or -1 if there is none. Unlike GroupIDs,
+// null keys never match.
+func Matches(build, probe []vector.Vector) (ids []int, nGroups int, probeIDs []int, err error) {
+	ids, t, err := groupIDs(build)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	hashes, err := HashRows(probe)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+	eq, err := RowsEqual(build, probe)
+	if err != nil {
+		return nil, 0, nil, err
+	}
+
+	probeIDs = make([]int, len(hashes))
+	compute.ParallelChunks(len(hashes), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if anyNull(probe, i) {
+				probeIDs[i] = -1
+				continue
+			}
+			probeIDs[i] = t.find(hashes[i], i, eq)
+		}
+	})
+	return ids, t.len(), probeIDs, nil
+}
+
+// anyNull returns whether row i is null in any of the key vectors
+func anyNull(keys []vector.Vector, i int) bool {
+	for _, k := range keys {
+		if k.IsNull(i) {
+			return true
+		}
+	}
+	return false
+}
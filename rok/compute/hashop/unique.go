@@ -0,0 +1,91 @@
+package hashop
+
+import (
+	"sort"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Unique returns the distinct elements of v, in order of first occurrence; elements are distinct as
+// with RowsEqual, so null is kept once, as are float NaN values.
+func Unique(v vector.Vector) (vector.Vector, error) {
+	_, firstRows, err := GroupIDs([]vector.Vector{v})
+	if err != nil {
+		return nil, err
+	}
+	return selop.Take(v, firstRows)
+}
+
+// NUnique returns the number of distinct elements of v (see Unique); null counts as one distinct element
+func NUnique(v vector.Vector) (int, error) {
+	_, t, err := groupIDs([]vector.Vector{v})
+	if err != nil {
+		return 0, err
+	}
+	return t.len(), nil
+}
+
+// ValueCounts returns the distinct elements of v (see Unique), and the number of occurrences of each,
+// sorted by descending count; elements of equal count stay in order of first occurrence.
+func ValueCounts(v vector.Vector) (values vector.Vector, counts *vector.NumericVector[int64], err error) {
+	ids, firstRows, err := GroupIDs([]vector.Vector{v})
+	if err != nil {
+		return nil, nil, err
+	}
+	groupCounts := countIDs(ids, len(firstRows))
+
+	order := make([]int, len(firstRows))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return groupCounts[order[i]] > groupCounts[order[j]]
+	})
+
+	rows := make([]int, len(order))
+	countsBuff := make([]int64, len(order))
+	for i, id := range order {
+		rows[i] = firstRows[id]
+		countsBuff[i] = int64(groupCounts[id])
+	}
+	if values, err = selop.Take(v, rows); err != nil {
+		return nil, nil, err
+	}
+	counts = vector.NumericVecFromComponents(dtype.Int64{}, countsBuff, vector.NewValidityBitMap(len(countsBuff)))
+	return values, counts, nil
+}
+
+// IsDuplicated returns a BoolVector, evaluating whether each row of the key vectors occurs more than once;
+// every occurrence of a duplicated row is true, including the first. Rows are equal as with RowsEqual.
+func IsDuplicated(keys []vector.Vector) (*vector.BoolVector, error) {
+	ids, t, err := groupIDs(keys)
+	if err != nil {
+		return nil, err
+	}
+	groupCounts := countIDs(ids, t.len())
+
+	n := len(ids)
+	validity := vector.NewValidityBitMap(n)
+	dataBuff := make([]byte, validity.Len())
+	// chunks are divisible by 8; each worker owns whole bytes of the data buffer
+	compute.ParallelChunks(n, 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if groupCounts[ids[i]] > 1 {
+				dataBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+	})
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity), nil
+}
+
+// countIDs returns the number of rows with each group id
+func countIDs(ids []int, nGroups int) []int {
+	counts := make([]int, nGroups)
+	for _, id := range ids {
+		counts[id]++
+	}
+	return counts
+}
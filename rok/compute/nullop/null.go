@@ -0,0 +1,471 @@
+package nullop
+
+import (
+	"fmt"
+	"math"
+	"math/bits"
+	"time"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/dateop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// IsNull returns a BoolVector, evaluating whether each element of v is null; built from v's validity
+// bitmap a byte (8 elements) at a time
+func IsNull(v vector.Vector) *vector.BoolVector {
+	valid := v.Validity()
+	dataBuff := make([]byte, valid.Len())
+
+	compute.ParallelChunks(v.Len(), 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			dataBuff[i] = ^valid.Buffer[i]
+		}
+	})
+	// padding bits past the final element stay unset
+	if rem := v.Len() % 8; rem != 0 {
+		dataBuff[len(dataBuff)-1] &= byte(1)<<rem - 1
+	}
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, vector.NewValidityBitMap(v.Len()))
+}
+
+// IsNotNull returns a BoolVector, evaluating whether each element of v is not null; a copy of v's validity bitmap
+func IsNotNull(v vector.Vector) *vector.BoolVector {
+	return vector.BoolVecFromComponenets(dtype.Bool{}, v.Validity().DeepCopyBuff(), vector.NewValidityBitMap(v.Len()))
+}
+
+// FillNullForward returns v, with each null element replaced by the closest non-null element before it;
+// leading null elements stay null
+func FillNullForward(v vector.Vector) (vector.Vector, error) {
+	if v.NullCount() == 0 {
+		return v.Clone(), nil
+	}
+	return selop.Take(v, fillIndices(v, false))
+}
+
+// FillNullBackward returns v, with each null element replaced by the closest non-null element after it;
+// trailing null elements stay null
+func FillNullBackward(v vector.Vector) (vector.Vector, error) {
+	if v.NullCount() == 0 {
+		return v.Clone(), nil
+	}
+	return selop.Take(v, fillIndices(v, true))
+}
+
+// fillIndices returns, for each element of v, the index of the closest non-null element at or before it
+// (after it, if backward), or -1 if there is none; for use with selop.Take
+func fillIndices(v vector.Vector, backward bool) []int {
+	n := v.Len()
+	indices := make([]int, n)
+	valid := v.Validity()
+	chunkLast := make([]int, compute.NumWorkers)
+
+	// first pass: fill within each chunk, recording the chunk's last (first, if backward) non-null index
+	compute.ParallelChunks(n, 1, func(w, start, end int) {
+		last := -1
+		for k := start; k < end; k++ {
+			i := k
+			if backward {
+				i = start + end - 1 - k
+			}
+			if !valid.IsNull(i) {
+				last = i
+			}
+			indices[i] = last
+		}
+		chunkLast[w] = last
+	})
+
+	// carry of each chunk: the closest non-null index in the chunks before it (after it, if backward)
+	carry := make([]int, compute.NumWorkers)
+	last := -1
+	for k := range chunkLast {
+		w := k
+		if backward {
+			w = len(chunkLast) - 1 - k
+		}
+		carry[w] = last
+		if chunkLast[w] != -1 {
+			last = chunkLast[w]
+		}
+	}
+
+	// second pass: carry into each chunk's leading (trailing, if backward) run of nulls
+	compute.ParallelChunks(n, 1, func(w, start, end int) {
+		for k := start; k < end; k++ {
+			i := k
+			if backward {
+				i = start + end - 1 - k
+			}
+			if indices[i] != -1 {
+				break
+			}
+			indices[i] = carry[w]
+		}
+	})
+
+	return indices
+}
+
+// FillNull returns v, with each null element replaced by a literal fill value of v's element type: for a
+// NumericVector[T], any Go numeric value exactly representable as T (a float, for a float vector); a string or
+// []byte for a StringVector; a bool for a BoolVector; and for a DateVector, days since the Unix epoch as an
+// int32, or a time.Time (whose calendar date is taken, see dateop.DaysFromTime)
+func FillNull(v vector.Vector, lit any) (vector.Vector, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[uint16]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[uint32]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[uint64]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[int8]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[int16]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[int32]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[int64]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[int]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[float32]:
+		return fillNullNumeric(x, lit)
+	case *vector.NumericVector[float64]:
+		return fillNullNumeric(x, lit)
+	case *vector.DateVector:
+		var days int32
+		switch l := lit.(type) {
+		case int32:
+			days = l
+		case time.Time:
+			days = dateop.DaysFromTime(l)
+		default:
+			return nil, fillValueErr(v, lit)
+		}
+		if x.NullCount() == 0 {
+			return x.Clone(), nil
+		}
+		return vector.DateVecFromComponents(fillNullFixed(x.Data(), x.Validity(), days), vector.NewValidityBitMap(x.Len())), nil
+	case *vector.BoolVector:
+		l, ok := lit.(bool)
+		if !ok {
+			return nil, fillValueErr(v, lit)
+		}
+		if x.NullCount() == 0 {
+			return x.Clone(), nil
+		}
+		return fillNullBool(x, l), nil
+	case *vector.StringVector:
+		var l []byte
+		switch s := lit.(type) {
+		case string:
+			l = []byte(s)
+		case []byte:
+			l = s
+		default:
+			return nil, fillValueErr(v, lit)
+		}
+		if x.NullCount() == 0 {
+			return x.Clone(), nil
+		}
+		return fillNullString(x, l), nil
+	}
+	return nil, fmt.Errorf("unsupported type %v for fill null", v.Type())
+}
+
+func fillValueErr(v vector.Vector, lit any) error {
+	return fmt.Errorf("fill value %v (%T) does not match vector of type %v", lit, lit, v.Type())
+}
+
+func fillNullNumeric[T vector.Numeric](x *vector.NumericVector[T], lit any) (vector.Vector, error) {
+	l, ok := numericLit[T](lit)
+	if !ok {
+		return nil, fillValueErr(x, lit)
+	}
+	if x.NullCount() == 0 {
+		return x.Clone(), nil
+	}
+	return vector.NumericVecFromComponents(x.Type(), fillNullFixed(x.Data(), x.Validity(), l), vector.NewValidityBitMap(x.Len())), nil
+}
+
+// numericLit converts a Go numeric value to T; false if lit is not numeric, or is an integer out of the range
+// of an integer T, or a float that is not a whole number within it
+func numericLit[T vector.Numeric](lit any) (T, bool) {
+	switch l := lit.(type) {
+	case int:
+		return signedLit[T](int64(l))
+	case int8:
+		return signedLit[T](int64(l))
+	case int16:
+		return signedLit[T](int64(l))
+	case int32:
+		return signedLit[T](int64(l))
+	case int64:
+		return signedLit[T](l)
+	case uint:
+		return unsignedLit[T](uint64(l))
+	case uint8:
+		return unsignedLit[T](uint64(l))
+	case uint16:
+		return unsignedLit[T](uint64(l))
+	case uint32:
+		return unsignedLit[T](uint64(l))
+	case uint64:
+		return unsignedLit[T](l)
+	case float32:
+		return floatLit[T](float64(l))
+	case float64:
+		return floatLit[T](l)
+	}
+	return 0, false
+}
+
+func signedLit[T vector.Numeric](l int64) (T, bool) {
+	r := T(l)
+	return r, compute.IsFloat[T]() || (int64(r) == l && (r < 0) == (l < 0))
+}
+
+func unsignedLit[T vector.Numeric](l uint64) (T, bool) {
+	r := T(l)
+	return r, compute.IsFloat[T]() || (uint64(r) == l && r >= 0)
+}
+
+func floatLit[T vector.Numeric](l float64) (T, bool) {
+	if compute.IsFloat[T]() {
+		return T(l), true
+	}
+	// integer T holds [lo, hi); NaN is not a whole number
+	bitsT := vector.GetNumericDType(T(0)).BitsReq()
+	lo, hi := 0.0, math.Ldexp(1, bitsT)
+	if compute.IsSigned[T]() {
+		hi = math.Ldexp(1, bitsT-1)
+		lo = -hi
+	}
+	if l != math.Trunc(l) || l < lo || l >= hi {
+		return 0, false
+	}
+	return T(l), true
+}
+
+// fillNullFixed copies fixed-width data, setting null elements to lit; nulls are found a byte (8 elements)
+// at a time
+func fillNullFixed[T vector.Numeric](data []T, valid vector.ValidityBitMap, lit T) []T {
+	dataBuff := make([]T, len(data))
+	copy(dataBuff, data)
+
+	compute.ParallelChunks(len(dataBuff), 8, func(_, start, end int) {
+		for b := start / 8; b < (end+7)/8; b++ {
+			for null := ^valid.Buffer[b]; null != 0; null &= null - 1 {
+				if i := b*8 + bits.TrailingZeros8(null); i < len(dataBuff) {
+					dataBuff[i] = lit
+				}
+			}
+		}
+	})
+	return dataBuff
+}
+
+func fillNullBool(x *vector.BoolVector, lit bool) *vector.BoolVector {
+	var fill byte
+	if lit {
+		fill = 0xFF
+	}
+	valid := x.Validity().Buffer
+	dataBuff := make([]byte, len(valid))
+	for b := range dataBuff {
+		dataBuff[b] = x.Data()[b]&valid[b] | fill&^valid[b]
+	}
+	// padding bits past the final element stay unset
+	if rem := x.Len() % 8; rem != 0 {
+		dataBuff[len(dataBuff)-1] &= byte(1)<<rem - 1
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, vector.NewValidityBitMap(x.Len()))
+}
+
+// fillNullString copies strings in two passes: the first sizes each output element, the second fills it
+func fillNullString(x *vector.StringVector, lit []byte) *vector.StringVector {
+	n := x.Len()
+	newOffsetsBuffer := make([]int64, n+1)
+
+	compute.ParallelChunks(n, 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if x.IsNull(i) {
+				newOffsetsBuffer[i+1] = int64(len(lit))
+			} else {
+				newOffsetsBuffer[i+1] = int64(len(x.ValAt(i)))
+			}
+		}
+	})
+	for i := 0; i < n; i++ {
+		newOffsetsBuffer[i+1] += newOffsetsBuffer[i]
+	}
+	newDataBuffer := make([]byte, newOffsetsBuffer[n])
+
+	compute.ParallelChunks(n, 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			val := lit
+			if !x.IsNull(i) {
+				val = x.ValAt(i)
+			}
+			copy(newDataBuffer[newOffsetsBuffer[i]:newOffsetsBuffer[i+1]], val)
+		}
+	})
+
+	return vector.StringVecFromComponents(newDataBuffer, newOffsetsBuffer, vector.NewValidityBitMap(n))
+}
+
+// Coalesce returns the first non-null element of vecs at each position; vecs must share a type and length.
+func Coalesce(vecs ...vector.Vector) (vector.Vector, error) {
+	if len(vecs) == 0 {
+		return nil, fmt.Errorf("coalesce needs at least one vector")
+	}
+	for _, v := range vecs[1:] {
+		if v.Type().Type() != vecs[0].Type().Type() {
+			return nil, fmt.Errorf("mismatched types for coalesce: %v and %v", vecs[0].Type(), v.Type())
+		}
+		if v.Len() != vecs[0].Len() {
+			return nil, fmt.Errorf("mismatched lengths for coalesce: %d and %d", vecs[0].Len(), v.Len())
+		}
+	}
+
+	switch x := vecs[0].(type) {
+	case *vector.NumericVector[uint8]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[uint16]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[uint32]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[uint64]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[int8]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[int16]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[int32]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[int64]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[int]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[float32]:
+		return coalesceNumeric(x, vecs)
+	case *vector.NumericVector[float64]:
+		return coalesceNumeric(x, vecs)
+	case *vector.DateVector:
+		datas := make([][]int32, len(vecs))
+		for k, v := range vecs {
+			datas[k] = v.(*vector.DateVector).Data()
+		}
+		data, valid := coalesceFixed(datas, vecs)
+		return vector.DateVecFromComponents(data, valid), nil
+	case *vector.BoolVector:
+		return coalesceBool(vecs), nil
+	case *vector.StringVector:
+		return coalesceString(vecs), nil
+	}
+	return nil, fmt.Errorf("unsupported type %v for coalesce", vecs[0].Type())
+}
+
+func coalesceNumeric[T vector.Numeric](x *vector.NumericVector[T], vecs []vector.Vector) (vector.Vector, error) {
+	datas := make([][]T, len(vecs))
+	for k, v := range vecs {
+		y, ok := v.(*vector.NumericVector[T])
+		if !ok {
+			return nil, fmt.Errorf("mismatched types for coalesce: %v and %v", x.Type(), v.Type())
+		}
+		datas[k] = y.Data()
+	}
+	data, valid := coalesceFixed(datas, vecs)
+	return vector.NumericVecFromComponents(x.Type(), data, valid), nil
+}
+
+// coalesceFixed coalesces fixed-width data; for each later vector, only the elements that are null so far
+// and valid in that vector are copied, found a byte (8 elements) at a time
+func coalesceFixed[T vector.Numeric](datas [][]T, vecs []vector.Vector) ([]T, vector.ValidityBitMap) {
+	dataBuff := make([]T, len(datas[0]))
+	copy(dataBuff, datas[0])
+	validBuff := vecs[0].Validity().DeepCopyBuff()
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(len(dataBuff), 8, func(_, start, end int) {
+		for k := 1; k < len(vecs); k++ {
+			kValid := vecs[k].Validity().Buffer
+			for b := start / 8; b < (end+7)/8; b++ {
+				fill := kValid[b] &^ validBuff[b]
+				validBuff[b] |= fill
+				for ; fill != 0; fill &= fill - 1 {
+					i := b*8 + bits.TrailingZeros8(fill)
+					dataBuff[i] = datas[k][i]
+				}
+			}
+		}
+	})
+
+	return dataBuff, vector.ValidityBitMapFromBuff(validBuff, len(dataBuff))
+}
+
+func coalesceBool(vecs []vector.Vector) *vector.BoolVector {
+	x := vecs[0].(*vector.BoolVector)
+	dataBuff := make([]byte, x.Validity().Len())
+	copy(dataBuff, x.Data())
+	validBuff := x.Validity().DeepCopyBuff()
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for k := 1; k < len(vecs); k++ {
+			y := vecs[k].(*vector.BoolVector)
+			yData, yValid := y.Data(), y.Validity().Buffer
+			for b := start / 8; b < (end+7)/8; b++ {
+				fill := yValid[b] &^ validBuff[b]
+				dataBuff[b] = dataBuff[b]&^fill | yData[b]&fill
+				validBuff[b] |= fill
+			}
+		}
+	})
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, vector.ValidityBitMapFromBuff(validBuff, x.Len()))
+}
+
+// coalesceString finds the source vector of each element, then copies strings in two passes: the first
+// sizes each output element, the second fills it
+func coalesceString(vecs []vector.Vector) *vector.StringVector {
+	n := vecs[0].Len()
+	src := make([]int32, n)
+	validBuff := vecs[0].Validity().DeepCopyBuff()
+	newOffsetsBuffer := make([]int64, n+1)
+
+	compute.ParallelChunks(n, 8, func(_, start, end int) {
+		for k := 1; k < len(vecs); k++ {
+			kValid := vecs[k].Validity().Buffer
+			for b := start / 8; b < (end+7)/8; b++ {
+				fill := kValid[b] &^ validBuff[b]
+				validBuff[b] |= fill
+				for ; fill != 0; fill &= fill - 1 {
+					src[b*8+bits.TrailingZeros8(fill)] = int32(k)
+				}
+			}
+		}
+		for i := start; i < end; i++ {
+			if validBuff[i/8]&(1<<(i%8)) != 0 {
+				newOffsetsBuffer[i+1] = int64(len(vecs[src[i]].(*vector.StringVector).ValAt(i)))
+			}
+		}
+	})
+	for i := 0; i < n; i++ {
+		newOffsetsBuffer[i+1] += newOffsetsBuffer[i]
+	}
+	newDataBuffer := make([]byte, newOffsetsBuffer[n])
+
+	compute.ParallelChunks(n, 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			copy(newDataBuffer[newOffsetsBuffer[i]:newOffsetsBuffer[i+1]], vecs[src[i]].(*vector.StringVector).ValAt(i))
+		}
+	})
+
+	return vector.StringVecFromComponents(newDataBuffer, newOffsetsBuffer, vector.ValidityBitMapFromBuff(validBuff, n))
+}
@@ -0,0 +1,51 @@
+package compute
+
+import (
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// IsFloat returns whether T is a floating point type
+func IsFloat[T vector.Numeric]() bool {
+	half := 0.5
+	return T(half) != 0
+}
+
+// IsSigned returns whether T can hold negative values
+func IsSigned[T vector.Numeric]() bool {
+	var zero T
+	return zero-1 < zero
+}
+
+// Kahan is a running compensated sum (Kahan-Babuska-Neumaier); the zero value is an empty sum
+type Kahan struct {
+	sum float64
+	c   float64 // running compensation for lost low-order bits
+}
+
+// Add adds x to the sum
+func (k *Kahan) Add(x float64) {
+	t := k.sum + x
+	if math.Abs(k.sum) >= math.Abs(x) {
+		k.c += (k.sum - t) + x
+	} else {
+		k.c += (x - t) + k.sum
+	}
+	k.sum = t
+}
+
+// Merge adds another compensated sum to the sum, such as the partial sum of another worker
+func (k *Kahan) Merge(other Kahan) {
+	k.Add(other.sum)
+	k.Add(other.c)
+}
+
+// Result returns the compensated sum
+func (k Kahan) Result() float64 {
+	// an infinite sum leaves a NaN compensation term
+	if math.IsInf(k.sum, 0) {
+		return k.sum
+	}
+	return k.sum + k.c
+}
@@ -0,0 +1,290 @@
+package numop
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Sum returns the sum of the non-null elements of x; 0 if there are none.
+//
+// Floats are summed with compensated (Kahan-Babuska) summation. Integers are summed exactly; Sum
+// returns false if the integer sum overflows T.
+func Sum[T vector.Numeric](x *vector.NumericVector[T]) (T, bool) {
+	if compute.IsFloat[T]() {
+		return T(kahanSum(x).Result()), true
+	}
+
+	data, hasNulls := x.Data(), x.NullCount() > 0
+	parts := partials(x, func(start, end int) intSum[T] {
+		var sum intSum[T]
+		for i := start; i < end; i++ {
+			if hasNulls && x.IsNull(i) {
+				continue
+			}
+			sum.add(data[i])
+		}
+		return sum
+	})
+
+	var sum intSum[T]
+	for _, p := range parts {
+		sum.merge(p)
+	}
+	return sum.result()
+}
+
+// Product returns the product of the non-null elements of x; 1 if there are none.
+//
+// Integer products wrap on overflow, as with Go's * operator.
+func Product[T vector.Numeric](x *vector.NumericVector[T]) T {
+	data, hasNulls := x.Data(), x.NullCount() > 0
+	parts := partials(x, func(start, end int) T {
+		var prod T = 1
+		for i := start; i < end; i++ {
+			if hasNulls && x.IsNull(i) {
+				continue
+			}
+			prod *= data[i]
+		}
+		return prod
+	})
+
+	var prod T = 1
+	for _, p := range parts {
+		prod *= p
+	}
+	return prod
+}
+
+// Count returns the number of non-null elements of x
+func Count[T vector.Numeric](x *vector.NumericVector[T]) int {
+	return x.Len() - x.NullCount()
+}
+
+// CountNull returns the number of null elements of x
+func CountNull[T vector.Numeric](x *vector.NumericVector[T]) int {
+	return x.NullCount()
+}
+
+// Mean returns the arithmetic mean of the non-null elements of x, as a float64.
+//
+// Mean returns false if x has no non-null elements.
+func Mean[T vector.Numeric](x *vector.NumericVector[T]) (float64, bool) {
+	n := Count(x)
+	if n == 0 {
+		return 0, false
+	}
+	return kahanSum(x).Result() / float64(n), true
+}
+
+// Var returns the variance of the non-null elements of x, as a float64, with n - ddof degrees of freedom;
+// ddof = 0 gives the population variance, ddof = 1 the sample variance.
+//
+// Var returns false if x has no more than ddof non-null elements.
+func Var[T vector.Numeric](x *vector.NumericVector[T], ddof int) (float64, bool) {
+	m := moments(x)
+	if m.n <= float64(ddof) {
+		return 0, false
+	}
+	return m.m2 / (m.n - float64(ddof)), true
+}
+
+// Std returns the standard deviation of the non-null elements of x, with n - ddof degrees of freedom (see Var)
+func Std[T vector.Numeric](x *vector.NumericVector[T], ddof int) (float64, bool) {
+	v, ok := Var(x, ddof)
+	return math.Sqrt(v), ok
+}
+
+// Min returns the minimum of the non-null elements of x.
+//
+// Float NaN values are ignored, unless all non-null elements are NaN. Min returns false if
+// x has no non-null elements.
+func Min[T vector.Numeric](x *vector.NumericVector[T]) (T, bool) {
+	return extremum(x, func(a, b T) bool { return a < b })
+}
+
+// Max returns the maximum of the non-null elements of x.
+//
+// Float NaN values are ignored, unless all non-null elements are NaN. Max returns false if
+// x has no non-null elements.
+func Max[T vector.Numeric](x *vector.NumericVector[T]) (T, bool) {
+	return extremum(x, func(a, b T) bool { return a > b })
+}
+
+// partials runs fn over each worker's chunk of x in parallel, returning each worker's partial result
+func partials[T vector.Numeric, P any](x *vector.NumericVector[T], fn func(start, end int) P) []P {
+	parts := make([]P, compute.NumWorkers)
+	compute.ParallelChunks(x.Len(), 8, func(w, start, end int) {
+		parts[w] = fn(start, end)
+	})
+	return parts
+}
+
+// kahanSum returns the compensated float64 sum of the non-null elements of x
+func kahanSum[T vector.Numeric](x *vector.NumericVector[T]) compute.Kahan {
+	data, hasNulls := x.Data(), x.NullCount() > 0
+	parts := partials(x, func(start, end int) compute.Kahan {
+		var k compute.Kahan
+		for i := start; i < end; i++ {
+			if hasNulls && x.IsNull(i) {
+				continue
+			}
+			k.Add(float64(data[i]))
+		}
+		return k
+	})
+
+	var k compute.Kahan
+	for _, p := range parts {
+		k.Merge(p)
+	}
+	return k
+}
+
+// intSum is an exact integer sum, held as a 128-bit two's complement value
+type intSum[T vector.Numeric] struct {
+	hi int64
+	lo uint64
+}
+
+// add adds v to the sum, sign-extending signed values to 128 bits
+func (s *intSum[T]) add(v T) {
+	var carry uint64
+	if compute.IsSigned[T]() {
+		w := int64(v)
+		s.lo, carry = bits.Add64(s.lo, uint64(w), 0)
+		s.hi += w>>63 + int64(carry)
+		return
+	}
+	s.lo, carry = bits.Add64(s.lo, uint64(v), 0)
+	s.hi += int64(carry)
+}
+
+// merge adds the partial sum p to the sum
+func (s *intSum[T]) merge(p intSum[T]) {
+	var carry uint64
+	s.lo, carry = bits.Add64(s.lo, p.lo, 0)
+	s.hi += p.hi + int64(carry)
+}
+
+// result returns the sum as a T; false if it overflows T
+func (s intSum[T]) result() (T, bool) {
+	if compute.IsSigned[T]() {
+		w := int64(s.lo)
+		if r := T(w); s.hi == w>>63 && int64(r) == w {
+			return r, true
+		}
+		return 0, false
+	}
+	if r := T(s.lo); s.hi == 0 && uint64(r) == s.lo {
+		return r, true
+	}
+	return 0, false
+}
+
+// moment holds the count, mean and sum of squared deviations from the mean of a set of values
+type moment struct {
+	n    float64
+	mean float64
+	m2   float64
+}
+
+// add adds a value to the moments, following Welford's algorithm
+func (m *moment) add(v float64) {
+	m.n++
+	delta := v - m.mean
+	m.mean += delta / m.n
+	m.m2 += delta * (v - m.mean)
+}
+
+// merge combines the moments of two disjoint sets of values (Chan et al.)
+func (a moment) merge(b moment) moment {
+	if a.n == 0 {
+		return b
+	}
+	if b.n == 0 {
+		return a
+	}
+	n := a.n + b.n
+	delta := b.mean - a.mean
+	return moment{
+		n:    n,
+		mean: a.mean + delta*b.n/n,
+		m2:   a.m2 + b.m2 + delta*delta*a.n*b.n/n,
+	}
+}
+
+// moments returns the moments of the non-null elements of x
+func moments[T vector.Numeric](x *vector.NumericVector[T]) moment {
+	data, hasNulls := x.Data(), x.NullCount() > 0
+	parts := partials(x, func(start, end int) moment {
+		var m moment
+		for i := start; i < end; i++ {
+			if hasNulls && x.IsNull(i) {
+				continue
+			}
+			m.add(float64(data[i]))
+		}
+		return m
+	})
+
+	var m moment
+	for _, p := range parts {
+		m = m.merge(p)
+	}
+	return m
+}
+
+// extreme is a partial minimum or maximum
+type extreme[T vector.Numeric] struct {
+	val   T
+	found bool // a non-null, non-NaN value was seen
+	nan   bool // a non-null NaN value was seen
+}
+
+// add updates the partial extreme with v, if better(v, current) holds
+func (e *extreme[T]) add(v T, better func(a, b T) bool) {
+	// only NaN is not equal to itself
+	if v != v {
+		e.nan = true
+		return
+	}
+	if !e.found || better(v, e.val) {
+		e.val, e.found = v, true
+	}
+}
+
+// result returns the extreme value; NaN if only NaN values were seen
+func (e extreme[T]) result() (T, bool) {
+	if !e.found && e.nan {
+		return T(math.NaN()), true
+	}
+	return e.val, e.found
+}
+
+// extremum returns the non-null element of x for which better(element, others) holds, skipping NaN values
+func extremum[T vector.Numeric](x *vector.NumericVector[T], better func(a, b T) bool) (T, bool) {
+	data, hasNulls := x.Data(), x.NullCount() > 0
+	parts := partials(x, func(start, end int) extreme[T] {
+		var e extreme[T]
+		for i := start; i < end; i++ {
+			if hasNulls && x.IsNull(i) {
+				continue
+			}
+			e.add(data[i], better)
+		}
+		return e
+	})
+
+	var res extreme[T]
+	for _, p := range parts {
+		res.nan = res.nan || p.nan
+		if p.found && (!res.found || better(p.val, res.val)) {
+			res.val, res.found = p.val, true
+		}
+	}
+	return res.result()
+}
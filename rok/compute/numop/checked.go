@@ -0,0 +1,295 @@
+package numop
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+var (
+	// ErrOverflow is returned by checked kernels when an integer operation overflows
+	ErrOverflow = errors.New("integer overflow")
+	// ErrDivByZero is returned by checked kernels on integer division by zero
+	ErrDivByZero = errors.New("integer division by zero")
+	// ErrNegativeExponent is returned by checked kernels when an integer is raised to a negative power
+	ErrNegativeExponent = errors.New("negative integer exponent")
+)
+
+// CheckedAddVec returns the element-wise sum of two NumericVectors of type T, detecting integer overflow.
+//
+// Overflowing elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedAddVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedAdd[T])
+}
+
+// CheckedSubVec returns the element-wise difference of two NumericVectors of type T, detecting integer overflow.
+//
+// Overflowing elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedSubVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedSub[T])
+}
+
+// CheckedMulVec returns the element-wise product of two NumericVectors of type T, detecting integer overflow.
+//
+// Overflowing elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedMulVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedMul[T])
+}
+
+// CheckedDivVec returns the element-wise quotient of two NumericVectors of type T, detecting integer
+// division by zero and overflow (e.g. math.MinInt64 / -1).
+//
+// Failed elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedDivVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedDiv[T])
+}
+
+// CheckedModVec returns the element-wise remainder of two NumericVectors of type T (see ModVec),
+// detecting integer division by zero.
+//
+// Failed elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedModVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedMod[T])
+}
+
+// CheckedFloorDivVec returns the element-wise floor quotient of two NumericVectors of type T (see FloorDivVec),
+// detecting integer division by zero and overflow.
+//
+// Failed elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedFloorDivVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedFloorDiv[T])
+}
+
+// CheckedPowVec returns the element-wise exponent expression of two NumericVectors of type T, detecting integer
+// overflow and negative integer exponents.
+//
+// Failed elements are set to null, or reported as an error, depending on onFail; floats never fail.
+func CheckedPowVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedVec(x, y, onFail, checkedPow[T])
+}
+
+// CheckedAddLit returns the element-wise sum of a NumericVector of type T and literal value of type T,
+// detecting integer overflow (see CheckedAddVec)
+func CheckedAddLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedAdd[T])
+}
+
+// CheckedSubLit returns the element-wise difference of a NumericVector of type T and literal value of type T,
+// detecting integer overflow (see CheckedSubVec)
+func CheckedSubLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedSub[T])
+}
+
+// CheckedMulLit returns the element-wise product of a NumericVector of type T and literal value of type T,
+// detecting integer overflow (see CheckedMulVec)
+func CheckedMulLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedMul[T])
+}
+
+// CheckedDivLit returns the element-wise quotient of a NumericVector of type T and literal value of type T,
+// detecting integer division by zero and overflow (see CheckedDivVec)
+func CheckedDivLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedDiv[T])
+}
+
+// CheckedModLit returns the element-wise remainder of a NumericVector of type T and literal value of type T,
+// detecting integer division by zero (see CheckedModVec)
+func CheckedModLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedMod[T])
+}
+
+// CheckedFloorDivLit returns the element-wise floor quotient of a NumericVector of type T and literal value of type T,
+// detecting integer division by zero and overflow (see CheckedFloorDivVec)
+func CheckedFloorDivLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedFloorDiv[T])
+}
+
+// CheckedPowLit returns the element-wise exponent expression of a NumericVector of type T and literal value of type T,
+// detecting integer overflow and negative integer exponents (see CheckedPowVec)
+func CheckedPowLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail) (*vector.NumericVector[T], error) {
+	return checkedLit(x, lit, onFail, checkedPow[T])
+}
+
+// checkedVec performs the checked binary vector operation on two vectors, returning a resulting new vector.
+//
+// opFn returns the result of the operation, and an error if it failed.
+func checkedVec[T vector.Numeric](x, y *vector.NumericVector[T], onFail compute.OnFail, opFn func(x, y T) (T, error)) (*vector.NumericVector[T], error) {
+	dataBuff := make([]T, x.Len())
+	validBuff := make([]byte, x.Validity().Len())
+
+	xData, yData := x.Data(), y.Data()
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+	failures := compute.NewFailures(onFail)
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		andValidity(validBuff[start/8:(end+7)/8], xValid[start/8:(end+7)/8], yValid[start/8:(end+7)/8])
+		for i := start; i < end; i++ {
+			if isNullAt(validBuff, i) {
+				continue
+			}
+			res, err := opFn(xData[i], yData[i])
+			if err != nil {
+				failures.Record(validBuff, i, func() error {
+					return fmt.Errorf("%w at index %d: %v, %v", err, i, xData[i], yData[i])
+				})
+				continue
+			}
+			dataBuff[i] = res
+		}
+	})
+
+	if err := failures.Err(); err != nil {
+		return nil, err
+	}
+
+	validMap := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.NumericVecFromComponents(x.Type(), dataBuff, validMap), nil
+}
+
+// checkedLit performs the checked operation between a vector and literal numeric, returning a resulting new vector.
+func checkedLit[T vector.Numeric](x *vector.NumericVector[T], lit T, onFail compute.OnFail, opFn func(x, y T) (T, error)) (*vector.NumericVector[T], error) {
+	dataBuff := make([]T, x.Len())
+	validBuff := x.Validity().DeepCopyBuff()
+
+	xData := x.Data()
+	failures := compute.NewFailures(onFail)
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if isNullAt(validBuff, i) {
+				continue
+			}
+			res, err := opFn(xData[i], lit)
+			if err != nil {
+				failures.Record(validBuff, i, func() error {
+					return fmt.Errorf("%w at index %d: %v, %v", err, i, xData[i], lit)
+				})
+				continue
+			}
+			dataBuff[i] = res
+		}
+	})
+
+	if err := failures.Err(); err != nil {
+		return nil, err
+	}
+
+	validMap := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.NumericVecFromComponents(x.Type(), dataBuff, validMap), nil
+}
+
+func checkedAdd[T vector.Numeric](x, y T) (T, error) {
+	r := x + y
+	if compute.IsFloat[T]() {
+		return r, nil
+	}
+	if compute.IsSigned[T]() {
+		if (x > 0 && y > 0 && r < 0) || (x < 0 && y < 0 && r >= 0) {
+			return 0, ErrOverflow
+		}
+		return r, nil
+	}
+	if r < x {
+		return 0, ErrOverflow
+	}
+	return r, nil
+}
+
+func checkedSub[T vector.Numeric](x, y T) (T, error) {
+	r := x - y
+	if compute.IsFloat[T]() {
+		return r, nil
+	}
+	if compute.IsSigned[T]() {
+		if (y > 0 && r > x) || (y < 0 && r < x) {
+			return 0, ErrOverflow
+		}
+		return r, nil
+	}
+	if y > x {
+		return 0, ErrOverflow
+	}
+	return r, nil
+}
+
+func checkedMul[T vector.Numeric](x, y T) (T, error) {
+	r := x * y
+	if compute.IsFloat[T]() || x == 0 {
+		return r, nil
+	}
+	if r/x != y || (compute.IsSigned[T]() && isMinSigned(x) && y < 0) || (compute.IsSigned[T]() && isMinSigned(y) && x < 0) {
+		return 0, ErrOverflow
+	}
+	return r, nil
+}
+
+func checkedDiv[T vector.Numeric](x, y T) (T, error) {
+	if compute.IsFloat[T]() {
+		return x / y, nil
+	}
+	if y == 0 {
+		return 0, ErrDivByZero
+	}
+	var zero T
+	if compute.IsSigned[T]() && isMinSigned(x) && y == zero-1 {
+		return 0, ErrOverflow
+	}
+	return x / y, nil
+}
+
+func checkedMod[T vector.Numeric](x, y T) (T, error) {
+	if !compute.IsFloat[T]() && y == 0 {
+		return 0, ErrDivByZero
+	}
+	return mod(x, y), nil
+}
+
+func checkedFloorDiv[T vector.Numeric](x, y T) (T, error) {
+	if _, err := checkedDiv(x, y); err != nil {
+		return 0, err
+	}
+	return floorDiv(x, y), nil
+}
+
+// checkedPow raises x to the power y by repeated squaring, checking each product
+func checkedPow[T vector.Numeric](x, y T) (T, error) {
+	if compute.IsFloat[T]() {
+		return T(math.Pow(float64(x), float64(y))), nil
+	}
+	if y < 0 {
+		return 0, ErrNegativeExponent
+	}
+	res, base := T(1), x
+	var err error
+	for exp := uint64(y); exp > 0; {
+		if exp&1 == 1 {
+			if res, err = checkedMul(res, base); err != nil {
+				return 0, err
+			}
+		}
+		// the final square is never used, and may overflow
+		if exp >>= 1; exp > 0 {
+			if base, err = checkedMul(base, base); err != nil {
+				return 0, err
+			}
+		}
+	}
+	return res, nil
+}
+
+// isMinSigned returns whether x is the minimum value of a signed integer type; its negation overflows back to itself
+func isMinSigned[T vector.Numeric](x T) bool {
+	return x != 0 && x == -x
+}
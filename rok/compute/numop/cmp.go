@@ -0,0 +1,281 @@
+package numop
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// EqVec returns a BoolVector, evaluating whether each element of x is equal to the corresponding element of y
+//
+// EqVec will panic if both vectors are not of the same length
+func EqVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.BoolVector {
+	return cmpVec(x, y, eqVecChunk)
+}
+
+// NeVec returns a BoolVector, evaluating whether each element of x is not equal to the corresponding element of y
+//
+// NeVec will panic if both vectors are not of the same length
+func NeVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.BoolVector {
+	return cmpVec(x, y, neVecChunk)
+}
+
+// LtVec returns a BoolVector, evaluating whether each element of x is less than the corresponding element of y
+//
+// LtVec will panic if both vectors are not of the same length
+func LtVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.BoolVector {
+	return cmpVec(x, y, ltVecChunk)
+}
+
+// LeVec returns a BoolVector, evaluating whether each element of x is less than or equal to the corresponding element of y
+//
+// LeVec will panic if both vectors are not of the same length
+func LeVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.BoolVector {
+	return cmpVec(x, y, leVecChunk)
+}
+
+// GtVec returns a BoolVector, evaluating whether each element of x is greater than the corresponding element of y
+//
+// GtVec will panic if both vectors are not of the same length
+func GtVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.BoolVector {
+	return cmpVec(x, y, gtVecChunk)
+}
+
+// GeVec returns a BoolVector, evaluating whether each element of x is greater than or equal to the corresponding element of y
+//
+// GeVec will panic if both vectors are not of the same length
+func GeVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.BoolVector {
+	return cmpVec(x, y, geVecChunk)
+}
+
+// EqLit returns a BoolVector, evaluating whether each element of x is equal to a literal value of type T
+func EqLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	return cmpLit(x, lit, eqLitChunk)
+}
+
+// NeLit returns a BoolVector, evaluating whether each element of x is not equal to a literal value of type T
+func NeLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	return cmpLit(x, lit, neLitChunk)
+}
+
+// LtLit returns a BoolVector, evaluating whether each element of x is less than a literal value of type T
+func LtLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	return cmpLit(x, lit, ltLitChunk)
+}
+
+// LeLit returns a BoolVector, evaluating whether each element of x is less than or equal to a literal value of type T
+func LeLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	return cmpLit(x, lit, leLitChunk)
+}
+
+// GtLit returns a BoolVector, evaluating whether each element of x is greater than a literal value of type T
+func GtLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	return cmpLit(x, lit, gtLitChunk)
+}
+
+// GeLit returns a BoolVector, evaluating whether each element of x is greater than or equal to a literal value of type T
+func GeLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	return cmpLit(x, lit, geLitChunk)
+}
+
+// cmpVec performs the comparison on two vectors, returning a resulting BoolVector.
+func cmpVec[T vector.Numeric](x, y *vector.NumericVector[T], opFn func(out []byte, x, y []T)) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validBuff := make([]byte, x.Validity().Len())
+
+	xData, yData := x.Data(), y.Data()
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+
+	// chunks are divisible by 8; each worker owns whole bytes of the output bitmaps
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		startB, endB := start/8, (end+7)/8
+		opFn(dataBuff[startB:endB], xData[start:end], yData[start:end])
+		// bitwise AND to get new nulls; null slots are cleared to false
+		for i := startB; i < endB; i++ {
+			validBuff[i] = xValid[i] & yValid[i]
+			dataBuff[i] &= validBuff[i]
+		}
+	})
+
+	validMap := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validMap)
+}
+
+// cmpLit performs the comparison between a vector and literal, returning a resulting BoolVector.
+func cmpLit[T vector.Numeric](x *vector.NumericVector[T], lit T, opFn func(out []byte, x []T, lit T)) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validMap := x.Validity().DeepCopy()
+
+	xData := x.Data()
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		startB, endB := start/8, (end+7)/8
+		opFn(dataBuff[startB:endB], xData[start:end], lit)
+		// null slots are cleared to false
+		for i := startB; i < endB; i++ {
+			dataBuff[i] &= validMap.Buffer[i]
+		}
+	})
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validMap)
+}
+
+// element-wise vector comparison (==); packs 8 results per output byte
+func eqVecChunk[T vector.Numeric](out []byte, x, y []T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] == y[j] {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector comparison (!=); packs 8 results per output byte
+func neVecChunk[T vector.Numeric](out []byte, x, y []T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] != y[j] {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector comparison (<); packs 8 results per output byte
+func ltVecChunk[T vector.Numeric](out []byte, x, y []T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] < y[j] {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector comparison (<=); packs 8 results per output byte
+func leVecChunk[T vector.Numeric](out []byte, x, y []T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] <= y[j] {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector comparison (>); packs 8 results per output byte
+func gtVecChunk[T vector.Numeric](out []byte, x, y []T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] > y[j] {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector comparison (>=); packs 8 results per output byte
+func geVecChunk[T vector.Numeric](out []byte, x, y []T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] >= y[j] {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector scalar comparison (==); packs 8 results per output byte
+func eqLitChunk[T vector.Numeric](out []byte, x []T, lit T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] == lit {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector scalar comparison (!=); packs 8 results per output byte
+func neLitChunk[T vector.Numeric](out []byte, x []T, lit T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] != lit {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector scalar comparison (<); packs 8 results per output byte
+func ltLitChunk[T vector.Numeric](out []byte, x []T, lit T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] < lit {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector scalar comparison (<=); packs 8 results per output byte
+func leLitChunk[T vector.Numeric](out []byte, x []T, lit T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] <= lit {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector scalar comparison (>); packs 8 results per output byte
+func gtLitChunk[T vector.Numeric](out []byte, x []T, lit T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] > lit {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
+
+// element-wise vector scalar comparison (>=); packs 8 results per output byte
+func geLitChunk[T vector.Numeric](out []byte, x []T, lit T) {
+	for i := 0; i < len(x); i += 8 {
+		var b byte
+		for j := i; j < min(i+8, len(x)); j++ {
+			if x[j] >= lit {
+				b |= 1 << (j - i)
+			}
+		}
+		out[i/8] = b
+	}
+}
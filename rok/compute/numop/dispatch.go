@@ -0,0 +1,318 @@
+package numop
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Add returns the element-wise sum of two numeric vectors of any numeric DataType.
+//
+// Both vectors are first promoted to a common type (see PromoteTypes); Add returns an
+// error if either vector is not numeric
+func Add(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(addOp, x, y)
+}
+
+// Sub returns the element-wise difference of two numeric vectors of any numeric DataType (see Add)
+func Sub(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(subOp, x, y)
+}
+
+// Mul returns the element-wise product of two numeric vectors of any numeric DataType (see Add)
+func Mul(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(mulOp, x, y)
+}
+
+// Div returns the element-wise quotient of two numeric vectors of any numeric DataType (see Add).
+//
+// Integer division truncates towards zero; integer division by zero results in null
+func Div(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(divOp, x, y)
+}
+
+// Mod returns the element-wise remainder of two numeric vectors of any numeric DataType (see Add and ModVec).
+//
+// Integer division by zero results in null
+func Mod(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(modOp, x, y)
+}
+
+// FloorDiv returns the element-wise floor quotient of two numeric vectors of any numeric DataType (see Add).
+//
+// Integer division by zero results in null
+func FloorDiv(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(floorDivOp, x, y)
+}
+
+// Pow returns the element-wise exponent expression of two numeric vectors of any numeric DataType (see Add and PowVec)
+func Pow(x, y vector.Vector) (vector.Vector, error) {
+	return dispatchVec(powOp, x, y)
+}
+
+// Eq compares two numeric vectors of any numeric DataType for equality, after promotion (see Add)
+func Eq(x, y vector.Vector) (*vector.BoolVector, error) {
+	return dispatchCmp(eqOp, x, y)
+}
+
+// Ne compares two numeric vectors of any numeric DataType for inequality, after promotion (see Add)
+func Ne(x, y vector.Vector) (*vector.BoolVector, error) {
+	return dispatchCmp(neOp, x, y)
+}
+
+// Lt evaluates x < y element-wise for two numeric vectors of any numeric DataType, after promotion (see Add)
+func Lt(x, y vector.Vector) (*vector.BoolVector, error) {
+	return dispatchCmp(ltOp, x, y)
+}
+
+// Le evaluates x <= y element-wise for two numeric vectors of any numeric DataType, after promotion (see Add)
+func Le(x, y vector.Vector) (*vector.BoolVector, error) {
+	return dispatchCmp(leOp, x, y)
+}
+
+// Gt evaluates x > y element-wise for two numeric vectors of any numeric DataType, after promotion (see Add)
+func Gt(x, y vector.Vector) (*vector.BoolVector, error) {
+	return dispatchCmp(gtOp, x, y)
+}
+
+// Ge evaluates x >= y element-wise for two numeric vectors of any numeric DataType, after promotion (see Add)
+func Ge(x, y vector.Vector) (*vector.BoolVector, error) {
+	return dispatchCmp(geOp, x, y)
+}
+
+// PromoteTypes returns the common DataType two numeric DataTypes are promoted to, before a binary operation.
+//
+// The rules are:
+//   - identical types are left as-is
+//   - two floats promote to the wider float; an integer and a float promote to float64
+//   - two signed (or two unsigned) integers promote to the wider of the two
+//   - an unsigned and signed integer promote to the narrowest signed integer holding both,
+//     e.g. uint8 and int8 give int16, uint32 and int64 give int64
+//   - uint64 and any signed integer promote to float64, as no integer type holds both
+func PromoteTypes(x, y dtype.DataType) (dtype.DataType, error) {
+	xT, yT := x.Type(), y.Type()
+	if !dtype.IsNumeric(xT) || !dtype.IsNumeric(yT) {
+		return nil, fmt.Errorf("cannot promote non-numeric types %v and %v", x, y)
+	}
+	if xT == yT {
+		return x, nil
+	}
+
+	xBits, yBits := x.BitsReq(), y.BitsReq()
+	switch {
+	case dtype.IsFloat(xT) && dtype.IsFloat(yT):
+		return widerOf(x, y), nil
+	case dtype.IsFloat(xT) || dtype.IsFloat(yT):
+		return dtype.Float64{}, nil
+	case dtype.IsSignedInteger(xT) == dtype.IsSignedInteger(yT):
+		return widerOf(x, y), nil
+	}
+
+	// mixed signedness; the signed type needs more bits than the unsigned one
+	uBits, sBits := xBits, yBits
+	if dtype.IsSignedInteger(xT) {
+		uBits, sBits = yBits, xBits
+	}
+	switch bits := max(uBits*2, sBits); bits {
+	case 16:
+		return dtype.Int16{}, nil
+	case 32:
+		return dtype.Int32{}, nil
+	case 64:
+		return dtype.Int64{}, nil
+	}
+	return dtype.Float64{}, nil
+}
+
+func widerOf(x, y dtype.DataType) dtype.DataType {
+	if y.BitsReq() > x.BitsReq() {
+		return y
+	}
+	return x
+}
+
+// Promote converts a numeric vector to the numeric DataType `to`, returning the vector itself when no
+// conversion is needed. Values are converted as with a Go type conversion; Promote is intended for
+// widening conversions (see PromoteTypes).
+func Promote(v vector.Vector, to dtype.DataType) (vector.Vector, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[uint16]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[uint32]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[uint64]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[int8]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[int16]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[int32]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[int64]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[int]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[float32]:
+		return promoteFrom(x, to)
+	case *vector.NumericVector[float64]:
+		return promoteFrom(x, to)
+	}
+	return nil, fmt.Errorf("cannot promote non-numeric vector of type %v", v.Type())
+}
+
+func promoteFrom[F vector.Numeric](x *vector.NumericVector[F], to dtype.DataType) (vector.Vector, error) {
+	switch to.Type() {
+	case dtype.UINT8:
+		return convertTo[F, uint8](x, to), nil
+	case dtype.UINT16:
+		return convertTo[F, uint16](x, to), nil
+	case dtype.UINT32:
+		return convertTo[F, uint32](x, to), nil
+	case dtype.UINT64:
+		return convertTo[F, uint64](x, to), nil
+	case dtype.INT8:
+		return convertTo[F, int8](x, to), nil
+	case dtype.INT16:
+		return convertTo[F, int16](x, to), nil
+	case dtype.INT32:
+		return convertTo[F, int32](x, to), nil
+	case dtype.INT64:
+		return convertTo[F, int64](x, to), nil
+	case dtype.FLOAT32:
+		return convertTo[F, float32](x, to), nil
+	case dtype.FLOAT64:
+		return convertTo[F, float64](x, to), nil
+	}
+	return nil, fmt.Errorf("cannot promote %v to non-numeric type %v", x.Type(), to)
+}
+
+// convertTo converts a NumericVector of type F to type T, in parallel
+func convertTo[F, T vector.Numeric](x *vector.NumericVector[F], to dtype.DataType) *vector.NumericVector[T] {
+	if same, ok := any(x).(*vector.NumericVector[T]); ok {
+		return same
+	}
+
+	dataBuff := make([]T, x.Len())
+	xData := x.Data()
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			dataBuff[i] = T(xData[i])
+		}
+	})
+
+	return vector.NumericVecFromComponents(to, dataBuff, x.Validity().DeepCopy())
+}
+
+type binaryOp int
+
+const (
+	addOp binaryOp = iota
+	subOp
+	mulOp
+	divOp
+	modOp
+	floorDivOp
+	powOp
+	eqOp
+	neOp
+	ltOp
+	leOp
+	gtOp
+	geOp
+)
+
+// dispatchVec promotes both vectors to a common type, and applies the generic kernel for op
+func dispatchVec(op binaryOp, x, y vector.Vector) (vector.Vector, error) {
+	t, err := PromoteTypes(x.Type(), y.Type())
+	if err != nil {
+		return nil, err
+	}
+	if x.Len() != y.Len() {
+		return nil, fmt.Errorf("mismatched vector lengths %d and %d", x.Len(), y.Len())
+	}
+	if x, err = Promote(x, t); err != nil {
+		return nil, err
+	}
+	if y, err = Promote(y, t); err != nil {
+		return nil, err
+	}
+
+	switch xv := x.(type) {
+	case *vector.NumericVector[uint8]:
+		return applyOp(op, xv, y.(*vector.NumericVector[uint8]))
+	case *vector.NumericVector[uint16]:
+		return applyOp(op, xv, y.(*vector.NumericVector[uint16]))
+	case *vector.NumericVector[uint32]:
+		return applyOp(op, xv, y.(*vector.NumericVector[uint32]))
+	case *vector.NumericVector[uint64]:
+		return applyOp(op, xv, y.(*vector.NumericVector[uint64]))
+	case *vector.NumericVector[int8]:
+		return applyOp(op, xv, y.(*vector.NumericVector[int8]))
+	case *vector.NumericVector[int16]:
+		return applyOp(op, xv, y.(*vector.NumericVector[int16]))
+	case *vector.NumericVector[int32]:
+		return applyOp(op, xv, y.(*vector.NumericVector[int32]))
+	case *vector.NumericVector[int64]:
+		return applyOp(op, xv, y.(*vector.NumericVector[int64]))
+	case *vector.NumericVector[float32]:
+		return applyOp(op, xv, y.(*vector.NumericVector[float32]))
+	case *vector.NumericVector[float64]:
+		return applyOp(op, xv, y.(*vector.NumericVector[float64]))
+	}
+	return nil, fmt.Errorf("unsupported vector of type %v", x.Type())
+}
+
+// dispatchCmp promotes both vectors to a common type, and applies the generic comparison kernel for op
+func dispatchCmp(op binaryOp, x, y vector.Vector) (*vector.BoolVector, error) {
+	res, err := dispatchVec(op, x, y)
+	if err != nil {
+		return nil, err
+	}
+	return res.(*vector.BoolVector), nil
+}
+
+func applyOp[T vector.Numeric](op binaryOp, x, y *vector.NumericVector[T]) (vector.Vector, error) {
+	switch op {
+	case addOp:
+		return AddVec(x, y), nil
+	case subOp:
+		return SubVec(x, y), nil
+	case mulOp:
+		return MulVec(x, y), nil
+	case divOp:
+		if compute.IsFloat[T]() {
+			return DivVec(x, y), nil
+		}
+		return CheckedDivVec(x, y, compute.NullOnFail)
+	case modOp:
+		if compute.IsFloat[T]() {
+			return ModVec(x, y), nil
+		}
+		return CheckedModVec(x, y, compute.NullOnFail)
+	case floorDivOp:
+		if compute.IsFloat[T]() {
+			return FloorDivVec(x, y), nil
+		}
+		return CheckedFloorDivVec(x, y, compute.NullOnFail)
+	case powOp:
+		return PowVec(x, y), nil
+	case eqOp:
+		return EqVec(x, y), nil
+	case neOp:
+		return NeVec(x, y), nil
+	case ltOp:
+		return LtVec(x, y), nil
+	case leOp:
+		return LeVec(x, y), nil
+	case gtOp:
+		return GtVec(x, y), nil
+	case geOp:
+		return GeVec(x, y), nil
+	}
+	return nil, fmt.Errorf("unsupported operator")
+}
@@ -0,0 +1,131 @@
+package numop
+
+import (
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// DivVec returns the element-wise quotient of two NumericVectors of type T
+//
+// DivVec will panic if both vectors are not of the same length; will also panic on integer
+// division by zero in a non-null slot (see CheckedDivVec to null those slots instead)
+func DivVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.NumericVector[T] {
+	return opVec(x, y, divVecChunk)
+}
+
+// ModVec returns the element-wise remainder of the truncated division of two NumericVectors of type T;
+// as with Go's % operator, the result takes the sign of x. Floats follow math.Mod.
+//
+// ModVec will panic if both vectors are not of the same length; will also panic on integer
+// division by zero in a non-null slot (see CheckedModVec to null those slots instead)
+func ModVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.NumericVector[T] {
+	return opVec(x, y, modVecChunk)
+}
+
+// FloorDivVec returns the element-wise quotient of two NumericVectors of type T, rounded towards negative infinity
+//
+// FloorDivVec will panic if both vectors are not of the same length; will also panic on integer
+// division by zero in a non-null slot (see CheckedFloorDivVec to null those slots instead)
+func FloorDivVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.NumericVector[T] {
+	return opVec(x, y, floorDivVecChunk)
+}
+
+// ModLit returns the element-wise remainder of the truncated division of a NumericVector of type T and literal value of type T
+//
+// ModLit will panic on integer division by zero
+func ModLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.NumericVector[T] {
+	return opLit(x, lit, modLitChunk)
+}
+
+// FloorDivLit returns the element-wise quotient of a NumericVector of type T and literal value of type T,
+// rounded towards negative infinity
+//
+// FloorDivLit will panic on integer division by zero
+func FloorDivLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.NumericVector[T] {
+	return opLit(x, lit, floorDivLitChunk)
+}
+
+// element-wise vector quotient; null integer slots are skipped, so a null divisor never panics
+func divVecChunk[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
+	andValidity(outB, xB, yB)
+	skipNull := !compute.IsFloat[T]()
+	for i := 0; i < len(out); i++ {
+		if skipNull && y[i] == 0 && isNullAt(outB, i) {
+			continue
+		}
+		out[i] = x[i] / y[i]
+	}
+}
+
+// element-wise vector remainder
+func modVecChunk[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
+	andValidity(outB, xB, yB)
+	skipNull := !compute.IsFloat[T]()
+	for i := 0; i < len(out); i++ {
+		if skipNull && y[i] == 0 && isNullAt(outB, i) {
+			continue
+		}
+		out[i] = mod(x[i], y[i])
+	}
+}
+
+// element-wise vector floor quotient
+func floorDivVecChunk[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
+	andValidity(outB, xB, yB)
+	skipNull := !compute.IsFloat[T]()
+	for i := 0; i < len(out); i++ {
+		if skipNull && y[i] == 0 && isNullAt(outB, i) {
+			continue
+		}
+		out[i] = floorDiv(x[i], y[i])
+	}
+}
+
+// element wise vector scalar remainder
+func modLitChunk[T vector.Numeric](out, x []T, lit T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = mod(x[i], lit)
+	}
+}
+
+// element wise vector scalar floor quotient
+func floorDivLitChunk[T vector.Numeric](out, x []T, lit T) {
+	for i := 0; i < len(out); i++ {
+		out[i] = floorDiv(x[i], lit)
+	}
+}
+
+// mod returns the remainder of x / y, truncated towards zero
+func mod[T vector.Numeric](x, y T) T {
+	if compute.IsFloat[T]() {
+		return T(math.Mod(float64(x), float64(y)))
+	}
+	// generic T does not allow %; equivalent for integers
+	return x - (x/y)*y
+}
+
+// floorDiv returns x / y, rounded towards negative infinity
+func floorDiv[T vector.Numeric](x, y T) T {
+	if compute.IsFloat[T]() {
+		return T(math.Floor(float64(x) / float64(y)))
+	}
+	q := x / y
+	// truncated towards zero; step down when the signs differ and there's a remainder
+	if r := x - q*y; r != 0 && (r < 0) != (y < 0) {
+		q--
+	}
+	return q
+}
+
+// andValidity sets out to the bitwise AND of two validity byte slices
+func andValidity(out, x, y []byte) {
+	for i := 0; i < len(out); i++ {
+		out[i] = x[i] & y[i]
+	}
+}
+
+func isNullAt(b []byte, i int) bool {
+	return (b[i/8]>>(i%8))&1 == 0
+}
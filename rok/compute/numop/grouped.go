@@ -0,0 +1,148 @@
+package numop
+
+import (
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// GroupSum returns the sum of the non-null elements of x in each group (see Sum); a group with
+// no non-null elements sums to 0, and a group whose integer sum overflows T is null
+func GroupSum[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups) *vector.NumericVector[T] {
+	data := x.Data()
+	if compute.IsFloat[T]() {
+		return groupReduce(g, x.Type(), func(rows []int) (T, bool) {
+			var k compute.Kahan
+			for _, r := range rows {
+				if !x.IsNull(r) {
+					k.Add(float64(data[r]))
+				}
+			}
+			return T(k.Result()), true
+		})
+	}
+	return groupReduce(g, x.Type(), func(rows []int) (T, bool) {
+		var sum intSum[T]
+		for _, r := range rows {
+			if !x.IsNull(r) {
+				sum.add(data[r])
+			}
+		}
+		return sum.result()
+	})
+}
+
+// GroupProduct returns the product of the non-null elements of x in each group (see Product); a group
+// with no non-null elements gives 1
+func GroupProduct[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups) *vector.NumericVector[T] {
+	data := x.Data()
+	return groupReduce(g, x.Type(), func(rows []int) (T, bool) {
+		var prod T = 1
+		for _, r := range rows {
+			if !x.IsNull(r) {
+				prod *= data[r]
+			}
+		}
+		return prod, true
+	})
+}
+
+// GroupMean returns the mean of the non-null elements of x in each group, as a float64 vector;
+// a group with no non-null elements is null
+func GroupMean[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups) *vector.NumericVector[float64] {
+	data := x.Data()
+	return groupReduce(g, dtype.Float64{}, func(rows []int) (float64, bool) {
+		var k compute.Kahan
+		var n int
+		for _, r := range rows {
+			if !x.IsNull(r) {
+				k.Add(float64(data[r]))
+				n++
+			}
+		}
+		return k.Result() / float64(n), n > 0
+	})
+}
+
+// GroupVar returns the variance of the non-null elements of x in each group, with n - ddof degrees of
+// freedom (see Var); a group with no more than ddof non-null elements is null
+func GroupVar[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups, ddof int) *vector.NumericVector[float64] {
+	return groupVar(x, g, ddof, false)
+}
+
+// GroupStd returns the standard deviation of the non-null elements of x in each group, with n - ddof degrees of
+// freedom (see GroupVar)
+func GroupStd[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups, ddof int) *vector.NumericVector[float64] {
+	return groupVar(x, g, ddof, true)
+}
+
+func groupVar[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups, ddof int, std bool) *vector.NumericVector[float64] {
+	data := x.Data()
+	return groupReduce(g, dtype.Float64{}, func(rows []int) (float64, bool) {
+		var m moment
+		for _, r := range rows {
+			if !x.IsNull(r) {
+				m.add(float64(data[r]))
+			}
+		}
+		if m.n <= float64(ddof) {
+			return 0, false
+		}
+		v := m.m2 / (m.n - float64(ddof))
+		if std {
+			return math.Sqrt(v), true
+		}
+		return v, true
+	})
+}
+
+// GroupMin returns the minimum of the non-null elements of x in each group (see Min); a group with
+// no non-null elements is null
+func GroupMin[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups) *vector.NumericVector[T] {
+	return groupExtremum(x, g, func(a, b T) bool { return a < b })
+}
+
+// GroupMax returns the maximum of the non-null elements of x in each group (see Max); a group with
+// no non-null elements is null
+func GroupMax[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups) *vector.NumericVector[T] {
+	return groupExtremum(x, g, func(a, b T) bool { return a > b })
+}
+
+func groupExtremum[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups, better func(a, b T) bool) *vector.NumericVector[T] {
+	data := x.Data()
+	return groupReduce(g, x.Type(), func(rows []int) (T, bool) {
+		var e extreme[T]
+		for _, r := range rows {
+			if !x.IsNull(r) {
+				e.add(data[r], better)
+			}
+		}
+		return e.result()
+	})
+}
+
+// groupReduce runs fn on the rows of each group, in parallel across groups, returning a vector of
+// each group's result; groups for which fn returns false are null
+func groupReduce[R vector.Numeric](g *compute.Groups, dType dtype.DataType, fn func(rows []int) (R, bool)) *vector.NumericVector[R] {
+	dataBuff := make([]R, g.Len())
+	validBuff := make([]byte, (g.Len()+7)/8)
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(g.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if val, ok := fn(g.GroupRows(i)); ok {
+				dataBuff[i] = val
+				validBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+	})
+
+	validMap := vector.ValidityBitMap{
+		TrueLen:   g.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, g.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.NumericVecFromComponents(dType, dataBuff, validMap)
+}
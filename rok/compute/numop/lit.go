@@ -5,6 +5,7 @@ import (
 	"sync"
 
 	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
 	"github.com/rhawrami/rok-frame/rok/vector"
 )
 
@@ -36,13 +37,27 @@ func DivLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.Numeric
 }
 
 // PowLit returns the element-wise exponent expression of a NumericVector of type T and literal value of type T
+//
+// Floats follow math.Pow. Integers are raised by repeated squaring, wrapping on overflow as with MulLit;
+// a negative integer exponent gives null elements.
 func PowLit[T vector.Numeric](x *vector.NumericVector[T], lit T) *vector.NumericVector[T] {
+	if !compute.IsFloat[T]() && lit < 0 {
+		n := x.Len()
+		nulls := vector.ValidityBitMap{TrueLen: n, NullCount: n, Buffer: make([]byte, (n+7)/8)}
+		return vector.NumericVecFromComponents(x.Type(), make([]T, n), nulls)
+	}
 	return opLit(x, lit, powLitChunk)
 }
 
 // opLit performs the vector operation between a vector and literal numeric, returning a resulting new vector.
 func opLit[T vector.Numeric](x *vector.NumericVector[T], lit T, opFn func(out, x []T, lit T)) *vector.NumericVector[T] {
-	dataBuff := make([]T, x.Len())
+	return opUnary(x, x.Type(), func(out, x []T) { opFn(out, x, lit) })
+}
+
+// opUnary performs the element-wise operation on a vector, returning a resulting new vector of DataType outType,
+// with the same validity.
+func opUnary[T, U vector.Numeric](x *vector.NumericVector[T], outType dtype.DataType, opFn func(out []U, x []T)) *vector.NumericVector[U] {
+	dataBuff := make([]U, x.Len())
 	validMap := x.Validity().DeepCopy()
 	// break up chunks; make divisible by 8; final chunk will often not equal len of others
 	chunkSize := x.Len() / (compute.NumWorkers * 8) * 8
@@ -65,14 +80,13 @@ func opLit[T vector.Numeric](x *vector.NumericVector[T], lit T, opFn func(out, x
 			opFn(
 				dataBuff[startData:endData],
 				xData[startData:endData],
-				lit,
 			)
 		}(i)
 	}
 	wg.Wait()
 
 	return vector.NumericVecFromComponents(
-		x.Type(),
+		outType,
 		dataBuff,
 		validMap,
 	)
@@ -106,9 +120,27 @@ func divLitChunk[T vector.Numeric](out, x []T, lit T) {
 	}
 }
 
-// element wise exponent expression
+// element wise exponent expression; lit is non-negative for integers
 func powLitChunk[T vector.Numeric](out, x []T, lit T) {
+	if !compute.IsFloat[T]() {
+		for i := 0; i < len(out); i++ {
+			out[i] = intPow(x[i], uint64(lit))
+		}
+		return
+	}
 	for i := 0; i < len(out); i++ {
 		out[i] = T(math.Pow(float64(x[i]), float64(lit)))
 	}
 }
+
+// intPow raises an integer to a non-negative integer power by repeated squaring, wrapping on overflow
+func intPow[T vector.Numeric](base T, exp uint64) T {
+	res := T(1)
+	for ; exp > 0; exp >>= 1 {
+		if exp&1 == 1 {
+			res *= base
+		}
+		base *= base
+	}
+	return res
+}
@@ -0,0 +1,300 @@
+package numop
+
+import (
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Math functions follow Go's math package (IEEE 754) on their domain edges: NaN elements stay NaN,
+// out-of-domain elements become NaN (e.g. Sqrt(-1), Log(-1), Asin(2)), and poles become infinite
+// (e.g. Log(0) is -Inf). Null elements stay null; no function adds nulls. Functions which may return
+// non-integer values return float64 vectors, whatever the type of x.
+
+// Abs returns the element-wise absolute value of a NumericVector of type T.
+//
+// As with Go's - operator, the absolute value of a signed integer's minimum value wraps to itself
+func Abs[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[T] {
+	return opUnary(x, x.Type(), absChunk[T])
+}
+
+// Sign returns the element-wise sign of a NumericVector of type T: -1, 0 or 1. Float NaN and signed
+// zero elements keep their value.
+func Sign[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[T] {
+	return opUnary(x, x.Type(), signChunk[T])
+}
+
+// Sqrt returns the element-wise square root of a NumericVector of type T, as a float64
+func Sqrt[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Sqrt)
+}
+
+// Cbrt returns the element-wise cube root of a NumericVector of type T, as a float64
+func Cbrt[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Cbrt)
+}
+
+// Exp returns the element-wise base-e exponential of a NumericVector of type T, as a float64
+func Exp[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Exp)
+}
+
+// Log returns the element-wise natural logarithm of a NumericVector of type T, as a float64
+func Log[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Log)
+}
+
+// Log10 returns the element-wise base-10 logarithm of a NumericVector of type T, as a float64
+func Log10[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Log10)
+}
+
+// Log2 returns the element-wise base-2 logarithm of a NumericVector of type T, as a float64
+func Log2[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Log2)
+}
+
+// Sin returns the element-wise sine of a NumericVector of type T, in radians, as a float64
+func Sin[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Sin)
+}
+
+// Cos returns the element-wise cosine of a NumericVector of type T, in radians, as a float64
+func Cos[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Cos)
+}
+
+// Tan returns the element-wise tangent of a NumericVector of type T, in radians, as a float64
+func Tan[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Tan)
+}
+
+// Asin returns the element-wise arcsine of a NumericVector of type T, in radians, as a float64
+func Asin[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Asin)
+}
+
+// Acos returns the element-wise arccosine of a NumericVector of type T, in radians, as a float64
+func Acos[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Acos)
+}
+
+// Atan returns the element-wise arctangent of a NumericVector of type T, in radians, as a float64
+func Atan[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Atan)
+}
+
+// Sinh returns the element-wise hyperbolic sine of a NumericVector of type T, as a float64
+func Sinh[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Sinh)
+}
+
+// Cosh returns the element-wise hyperbolic cosine of a NumericVector of type T, as a float64
+func Cosh[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Cosh)
+}
+
+// Tanh returns the element-wise hyperbolic tangent of a NumericVector of type T, as a float64
+func Tanh[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opFloat(x, math.Tanh)
+}
+
+// Round returns a NumericVector of type T, rounding each element to the given number of decimal places,
+// with halves rounded away from zero. Negative decimals round to the left of the decimal point, e.g.
+// Round(x, -2) rounds 1250 to 1300.
+//
+// Integers are left as-is for decimals >= 0; a rounded integer outside of the range of T wraps, as with
+// Go's * operator. Floats are rounded after scaling by a power of 10, so they carry the binary
+// representation error of the scaled value; floats too large to have a fractional part are left as-is.
+func Round[T vector.Numeric](x *vector.NumericVector[T], decimals int) *vector.NumericVector[T] {
+	if compute.IsFloat[T]() {
+		return opUnary(x, x.Type(), func(out, x []T) {
+			roundFloatChunk(out, x, decimals)
+		})
+	}
+	if decimals >= 0 {
+		return x.Clone().(*vector.NumericVector[T])
+	}
+	return opUnary(x, x.Type(), func(out, x []T) {
+		roundIntChunk(out, x, -decimals)
+	})
+}
+
+// Floor returns a NumericVector of type T, rounding each element towards negative infinity; integers are left as-is
+func Floor[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[T] {
+	if !compute.IsFloat[T]() {
+		return x.Clone().(*vector.NumericVector[T])
+	}
+	return opUnary(x, x.Type(), func(out, x []T) {
+		for i := 0; i < len(out); i++ {
+			out[i] = T(math.Floor(float64(x[i])))
+		}
+	})
+}
+
+// Ceil returns a NumericVector of type T, rounding each element towards positive infinity; integers are left as-is
+func Ceil[T vector.Numeric](x *vector.NumericVector[T]) *vector.NumericVector[T] {
+	if !compute.IsFloat[T]() {
+		return x.Clone().(*vector.NumericVector[T])
+	}
+	return opUnary(x, x.Type(), func(out, x []T) {
+		for i := 0; i < len(out); i++ {
+			out[i] = T(math.Ceil(float64(x[i])))
+		}
+	})
+}
+
+// Clip returns a NumericVector of type T, limiting each element to the range [lo, hi]; NaN elements stay NaN.
+//
+// lo should not be greater than hi; if it is, elements less than lo are set to lo, and the rest to hi
+func Clip[T vector.Numeric](x *vector.NumericVector[T], lo, hi T) *vector.NumericVector[T] {
+	return opUnary(x, x.Type(), func(out, x []T) {
+		for i := 0; i < len(out); i++ {
+			switch v := x[i]; {
+			case v < lo:
+				out[i] = lo
+			case v > hi:
+				out[i] = hi
+			default:
+				out[i] = v
+			}
+		}
+	})
+}
+
+// PowVec returns the element-wise exponent expression of two NumericVectors of type T, as with PowLit; integer
+// elements with a negative exponent are null
+//
+// PowVec will panic if both vectors are not of the same length
+func PowVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.NumericVector[T] {
+	return opVec(x, y, powVecChunk[T])
+}
+
+// Hypot returns the element-wise Euclidean norm sqrt(x*x + y*y) of two NumericVectors of type T,
+// without undue overflow or underflow, as a float64
+//
+// Hypot will panic if both vectors are not of the same length
+func Hypot[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.NumericVector[float64] {
+	return opVecTo(x, y, dtype.Float64{}, hypotVecChunk[T])
+}
+
+// opFloat applies the float64 function fn to each element of x
+func opFloat[T vector.Numeric](x *vector.NumericVector[T], fn func(float64) float64) *vector.NumericVector[float64] {
+	return opUnary(x, dtype.Float64{}, func(out []float64, x []T) {
+		for i := 0; i < len(out); i++ {
+			out[i] = fn(float64(x[i]))
+		}
+	})
+}
+
+// element-wise absolute value
+func absChunk[T vector.Numeric](out, x []T) {
+	for i := 0; i < len(out); i++ {
+		if v := x[i]; v < 0 {
+			out[i] = -v
+		} else if compute.IsFloat[T]() {
+			// clears the sign of -0
+			out[i] = T(math.Abs(float64(v)))
+		} else {
+			out[i] = v
+		}
+	}
+}
+
+// element-wise sign
+func signChunk[T vector.Numeric](out, x []T) {
+	for i := 0; i < len(out); i++ {
+		switch v := x[i]; {
+		case v < 0:
+			out[i] = 0
+			out[i]-- // generic T does not allow the constant -1
+		case v > 0:
+			out[i] = 1
+		default:
+			// zero, or NaN
+			out[i] = v
+		}
+	}
+}
+
+// element-wise float rounding to decimals places
+func roundFloatChunk[T vector.Numeric](out, x []T, decimals int) {
+	scale := math.Pow(10, math.Abs(float64(decimals)))
+	for i := 0; i < len(out); i++ {
+		v := float64(x[i])
+		var r float64
+		if decimals >= 0 {
+			scaled := v * scale
+			if math.IsInf(scale, 0) || math.IsInf(scaled, 0) || math.Abs(v) >= 1<<52 {
+				// no fractional digits to round
+				out[i] = x[i]
+				continue
+			}
+			r = math.Round(scaled) / scale
+		} else if math.IsInf(scale, 0) {
+			// every finite element rounds to zero
+			r = v
+			if !math.IsInf(v, 0) && !math.IsNaN(v) {
+				r = math.Copysign(0, v)
+			}
+		} else {
+			r = math.Round(v/scale) * scale
+		}
+		out[i] = T(r)
+	}
+}
+
+// element-wise integer rounding to a multiple of 10^digits, halves away from zero
+func roundIntChunk[T vector.Numeric](out, x []T, digits int) {
+	// find 10^digits, unless it overflows T; every element then rounds to 0
+	var p T = 1
+	for d := 0; d < digits; d++ {
+		next := p * 10
+		if next/10 != p || next < 0 {
+			clear(out)
+			return
+		}
+		p = next
+	}
+	for i := 0; i < len(out); i++ {
+		v := x[i]
+		q := v / p
+		r := v - q*p
+		// compare |r| with p - |r|, so as not to overflow on 2*|r|
+		switch {
+		case r > 0 && r >= p-r:
+			q++
+		case r < 0 && -r >= p+r:
+			q--
+		}
+		out[i] = q * p
+	}
+}
+
+// element-wise vector exponent expression
+func powVecChunk[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
+	andValidity(outB, xB, yB)
+	if !compute.IsFloat[T]() {
+		for i := 0; i < len(out); i++ {
+			if y[i] < 0 {
+				outB[i/8] &^= 1 << (i % 8)
+				continue
+			}
+			out[i] = intPow(x[i], uint64(y[i]))
+		}
+		return
+	}
+	for i := 0; i < len(out); i++ {
+		out[i] = T(math.Pow(float64(x[i]), float64(y[i])))
+	}
+}
+
+// element-wise vector Euclidean norm
+func hypotVecChunk[T vector.Numeric](out []float64, x, y []T, outB, xB, yB []byte) {
+	andValidity(outB, xB, yB)
+	for i := 0; i < len(out); i++ {
+		out[i] = math.Hypot(float64(x[i]), float64(y[i]))
+	}
+}
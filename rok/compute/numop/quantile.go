@@ -0,0 +1,65 @@
+package numop
+
+import (
+	"math"
+	"slices"
+
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Quantile returns the q-th quantile (0 <= q <= 1) of the non-null elements of x, as a float64, interpolating
+// linearly between the two closest ranks; e.g. q = 0.5 gives the median.
+//
+// Float NaN values are ignored, unless all non-null elements are NaN. Quantile returns false if x has no
+// non-null elements, or q is outside of [0, 1].
+func Quantile[T vector.Numeric](x *vector.NumericVector[T], q float64) (float64, bool) {
+	res, ok := Quantiles(x, q)
+	if !ok {
+		return 0, false
+	}
+	return res[0], true
+}
+
+// Quantiles returns several quantiles of the non-null elements of x (see Quantile), sorting x only once
+func Quantiles[T vector.Numeric](x *vector.NumericVector[T], qs ...float64) ([]float64, bool) {
+	for _, q := range qs {
+		if !(q >= 0 && q <= 1) {
+			return nil, false
+		}
+	}
+
+	data, hasNulls := x.Data(), x.NullCount() > 0
+	sorted := make([]T, 0, Count(x))
+	nan := false
+	for i, v := range data {
+		switch {
+		case hasNulls && x.IsNull(i):
+		case v != v:
+			nan = true
+		default:
+			sorted = append(sorted, v)
+		}
+	}
+	if len(sorted) == 0 {
+		if !nan {
+			return nil, false
+		}
+		res := make([]float64, len(qs))
+		for i := range res {
+			res[i] = math.NaN()
+		}
+		return res, true
+	}
+	slices.Sort(sorted)
+
+	res := make([]float64, len(qs))
+	for i, q := range qs {
+		pos := q * float64(len(sorted)-1)
+		lo := int(pos)
+		res[i] = float64(sorted[lo])
+		if frac := pos - float64(lo); frac > 0 {
+			res[i] += frac * (float64(sorted[lo+1]) - float64(sorted[lo]))
+		}
+	}
+	return res, true
+}
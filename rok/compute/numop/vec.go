@@ -4,6 +4,7 @@ import (
 	"sync"
 
 	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
 	"github.com/rhawrami/rok-frame/rok/vector"
 )
 
@@ -30,7 +31,12 @@ func MulVec[T vector.Numeric](x, y *vector.NumericVector[T]) *vector.NumericVect
 
 // opVec performs the binary vector operation on two vectors, returning a resulting new vector.
 func opVec[T vector.Numeric](x, y *vector.NumericVector[T], opFn func(out, x, y []T, outB, xB, yB []byte)) *vector.NumericVector[T] {
-	dataBuff := make([]T, x.Len())
+	return opVecTo(x, y, x.Type(), opFn)
+}
+
+// opVecTo performs the binary vector operation on two vectors, returning a resulting new vector of DataType outType.
+func opVecTo[T, U vector.Numeric](x, y *vector.NumericVector[T], outType dtype.DataType, opFn func(out []U, x, y []T, outB, xB, yB []byte)) *vector.NumericVector[U] {
+	dataBuff := make([]U, x.Len())
 	validBuff := make([]byte, x.Validity().Len())
 	// break up chunks; make divisible by 8; final chunk will often not equal len of others
 	chunkSize := x.Len() / (compute.NumWorkers * 8) * 8
@@ -74,7 +80,7 @@ func opVec[T vector.Numeric](x, y *vector.NumericVector[T], opFn func(out, x, y
 	}
 
 	return vector.NumericVecFromComponents(
-		x.Type(),
+		outType,
 		dataBuff,
 		validMap,
 	)
@@ -82,7 +88,7 @@ func opVec[T vector.Numeric](x, y *vector.NumericVector[T], opFn func(out, x, y
 
 // element-wise vector sum
 func addVecChunk8Incr[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
-	for i := 0; i < len(out); i += 8 {
+	for i := 0; i+8 <= len(out); i += 8 {
 		// unroll 8 elems
 		out[i] = x[i] + y[i]
 		out[i+1] = x[i+1] + y[i+1]
@@ -108,7 +114,7 @@ func addVecChunk8Incr[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
 
 // element-wise vector difference
 func subVecChunk8Incr[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
-	for i := 0; i < len(out); i += 8 {
+	for i := 0; i+8 <= len(out); i += 8 {
 		// unroll 8 elems
 		out[i] = x[i] - y[i]
 		out[i+1] = x[i+1] - y[i+1]
@@ -134,7 +140,7 @@ func subVecChunk8Incr[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
 
 // element-wise vector product
 func mulVecChunk8Incr[T vector.Numeric](out, x, y []T, outB, xB, yB []byte) {
-	for i := 0; i < len(out); i += 8 {
+	for i := 0; i+8 <= len(out); i += 8 {
 		// unroll 8 elems
 		out[i] = x[i] * y[i]
 		out[i+1] = x[i+1] * y[i+1]
@@ -0,0 +1,179 @@
+package selop
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Concat returns a new vector of the elements of each vector, in order; vectors must be of the same type.
+//
+// Validity bitmaps (and bool data) are merged bit by bit where a vector starts within a byte; string
+// offsets are rebased onto the merged data buffer.
+func Concat(vecs ...vector.Vector) (vector.Vector, error) {
+	if len(vecs) == 0 {
+		return nil, fmt.Errorf("Concat requires at least one vector")
+	}
+	switch vecs[0].(type) {
+	case *vector.NumericVector[uint8]:
+		return concatNumeric[uint8](vecs)
+	case *vector.NumericVector[uint16]:
+		return concatNumeric[uint16](vecs)
+	case *vector.NumericVector[uint32]:
+		return concatNumeric[uint32](vecs)
+	case *vector.NumericVector[uint64]:
+		return concatNumeric[uint64](vecs)
+	case *vector.NumericVector[int8]:
+		return concatNumeric[int8](vecs)
+	case *vector.NumericVector[int16]:
+		return concatNumeric[int16](vecs)
+	case *vector.NumericVector[int32]:
+		return concatNumeric[int32](vecs)
+	case *vector.NumericVector[int64]:
+		return concatNumeric[int64](vecs)
+	case *vector.NumericVector[int]:
+		return concatNumeric[int](vecs)
+	case *vector.NumericVector[float32]:
+		return concatNumeric[float32](vecs)
+	case *vector.NumericVector[float64]:
+		return concatNumeric[float64](vecs)
+	case *vector.StringVector:
+		return concatString(vecs)
+	case *vector.DateVector:
+		return concatDate(vecs)
+	case *vector.BoolVector:
+		return concatBool(vecs)
+	}
+	return nil, fmt.Errorf("Concat not supported for vector of type %v", vecs[0].Type())
+}
+
+// castAll asserts each vector to the vector type V
+func castAll[V vector.Vector](vecs []vector.Vector) ([]V, error) {
+	xs := make([]V, len(vecs))
+	for i, v := range vecs {
+		x, ok := v.(V)
+		if !ok {
+			return nil, fmt.Errorf("Concat expects vectors of type %v, got %v", vecs[0].Type(), v.Type())
+		}
+		xs[i] = x
+	}
+	return xs, nil
+}
+
+func concatNumeric[T vector.Numeric](vecs []vector.Vector) (vector.Vector, error) {
+	xs, err := castAll[*vector.NumericVector[T]](vecs)
+	if err != nil {
+		return nil, err
+	}
+	dataBuff := make([]T, 0, totalLen(vecs))
+	for _, x := range xs {
+		dataBuff = append(dataBuff, x.Data()...)
+	}
+	return vector.NumericVecFromComponents(xs[0].Type(), dataBuff, concatValidity(vecs)), nil
+}
+
+func concatDate(vecs []vector.Vector) (vector.Vector, error) {
+	xs, err := castAll[*vector.DateVector](vecs)
+	if err != nil {
+		return nil, err
+	}
+	dataBuff := make([]int32, 0, totalLen(vecs))
+	for _, x := range xs {
+		dataBuff = append(dataBuff, x.Data()...)
+	}
+	return vector.DateVecFromComponents(dataBuff, concatValidity(vecs)), nil
+}
+
+func concatBool(vecs []vector.Vector) (vector.Vector, error) {
+	xs, err := castAll[*vector.BoolVector](vecs)
+	if err != nil {
+		return nil, err
+	}
+	n := totalLen(vecs)
+	dataBuff := make([]byte, (n+7)/8)
+	off := 0
+	for _, x := range xs {
+		copyBits(dataBuff, off, x.Data(), x.Len())
+		off += x.Len()
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, concatValidity(vecs)), nil
+}
+
+func concatString(vecs []vector.Vector) (vector.Vector, error) {
+	xs, err := castAll[*vector.StringVector](vecs)
+	if err != nil {
+		return nil, err
+	}
+	var totalLenB int64
+	for _, x := range xs {
+		xOffsets := x.Offsets()
+		totalLenB += xOffsets[x.Len()] - xOffsets[0]
+	}
+
+	dataBuff := make([]byte, 0, totalLenB)
+	offsetsBuff := make([]int64, 0, totalLen(vecs)+1)
+	for _, x := range xs {
+		// offsets of x may not start at 0; rebase them onto the end of the data so far
+		xOffsets := x.Offsets()
+		base := int64(len(dataBuff)) - xOffsets[0]
+		for _, o := range xOffsets[:x.Len()] {
+			offsetsBuff = append(offsetsBuff, base+o)
+		}
+		dataBuff = append(dataBuff, x.Data()[xOffsets[0]:xOffsets[x.Len()]]...)
+	}
+	offsetsBuff = append(offsetsBuff, totalLenB)
+	return vector.StringVecFromComponents(dataBuff, offsetsBuff, concatValidity(vecs)), nil
+}
+
+// concatValidity merges the validity bitmaps of each vector
+func concatValidity(vecs []vector.Vector) vector.ValidityBitMap {
+	n := totalLen(vecs)
+	buff := make([]byte, (n+7)/8)
+	off, nullCount := 0, 0
+	for _, v := range vecs {
+		copyBits(buff, off, v.Validity().Buffer, v.Len())
+		off += v.Len()
+		nullCount += v.NullCount()
+	}
+	return vector.ValidityBitMap{TrueLen: n, NullCount: nullCount, Buffer: buff}
+}
+
+// copyBits copies the first n bits of src into the zeroed bits of dst, starting at bit dstOff; bits of src
+// past n are ignored
+func copyBits(dst []byte, dstOff int, src []byte, n int) {
+	if n == 0 {
+		return
+	}
+	nBytes, base, shift := (n+7)/8, dstOff/8, dstOff%8
+	var lastMask byte = 0xFF
+	if rem := n % 8; rem != 0 {
+		lastMask = byte(1)<<rem - 1
+	}
+
+	// byte-aligned start: a plain copy
+	if shift == 0 {
+		copy(dst[base:], src[:nBytes])
+		dst[base+nBytes-1] &= lastMask
+		return
+	}
+	// otherwise, each byte of src straddles two bytes of dst
+	for j := 0; j < nBytes; j++ {
+		b := src[j]
+		if j == nBytes-1 {
+			b &= lastMask
+		}
+		dst[base+j] |= b << shift
+		if hi := b >> (8 - shift); hi != 0 {
+			dst[base+j+1] |= hi
+		}
+	}
+}
+
+func totalLen(vecs []vector.Vector) int {
+	n := 0
+	for _, v := range vecs {
+		n += v.Len()
+	}
+	return n
+}
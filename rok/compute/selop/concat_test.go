@@ -0,0 +1,139 @@
+package selop
+
+import (
+	"testing"
+
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// bitAt returns bit i of a bitmap
+func bitAt(b []byte, i int) bool {
+	return b[i/8]&(1<<(i%8)) != 0
+}
+
+func TestCopyBits(t *testing.T) {
+	// bits past the first n of src are set, and must be ignored
+	src := []byte{0b10110101, 0b01101110, 0xFF}
+	tests := []struct {
+		dstOff int
+		n      int
+	}{
+		{0, 0}, {3, 0},
+		{0, 5}, {0, 8}, {0, 13}, {0, 16},
+		{1, 1}, {1, 7}, {1, 8}, {1, 13},
+		{2, 6}, {2, 15},
+		{3, 5}, {3, 9},
+		{4, 4}, {4, 12},
+		{5, 3}, {5, 11}, {5, 16},
+		{6, 2}, {6, 10},
+		{7, 1}, {7, 9}, {7, 16},
+		{9, 7}, {15, 14},
+	}
+	for _, tt := range tests {
+		// bits before dstOff are set, as though written by an earlier copy
+		dst := make([]byte, (tt.dstOff+tt.n+7)/8+1)
+		for i := 0; i < tt.dstOff; i++ {
+			dst[i/8] |= 1 << (i % 8)
+		}
+		copyBits(dst, tt.dstOff, src, tt.n)
+
+		for i := 0; i < len(dst)*8; i++ {
+			var want bool
+			switch {
+			case i < tt.dstOff:
+				want = true
+			case i < tt.dstOff+tt.n:
+				want = bitAt(src, i-tt.dstOff)
+			}
+			if got := bitAt(dst, i); got != want {
+				t.Errorf("copyBits(dstOff=%d, n=%d): bit %d = %v; want %v", tt.dstOff, tt.n, i, got, want)
+			}
+		}
+	}
+}
+
+func TestConcatValidity(t *testing.T) {
+	// validity of each vector; each following vector starts at bit offset len(first) % 8
+	tests := []struct {
+		name  string
+		valid [][]bool
+	}{
+		{"aligned", [][]bool{
+			{true, false, true, true, false, true, true, true},
+			{false, true, true},
+		}},
+		{"offset 1", [][]bool{
+			{false},
+			{true, true, false, true, true, true, true, false, true},
+		}},
+		{"offset 2", [][]bool{
+			{true, false},
+			{false, false, true, true, true, true, true, true},
+		}},
+		{"offset 3", [][]bool{
+			{true, true, true},
+			{true, false, true, false, true, false, true, false, true, false, true},
+		}},
+		{"offset 4", [][]bool{
+			{false, true, false, true},
+			{true, true, true, true, true},
+		}},
+		{"offset 5", [][]bool{
+			{true, true, true, true, false},
+			{false, false, false, false, false, false, false, false},
+		}},
+		{"offset 6", [][]bool{
+			{true, false, true, false, true, false},
+			{true},
+			{false, true, true},
+		}},
+		{"offset 7", [][]bool{
+			{true, true, true, true, true, true, false},
+			{true, false, true, true, true, true, true, true, true, true, false, true, true, true, true, false, true},
+		}},
+		{"empty", [][]bool{
+			{true, false, true},
+			{},
+			{false, true, true, true, true, true},
+		}},
+	}
+	for _, tt := range tests {
+		vecs := make([]vector.Vector, len(tt.valid))
+		var want []bool
+		for i, valid := range tt.valid {
+			validMap := vector.ValidityBitMapFromBools(valid)
+			// set padding bits, which must be ignored
+			if rem := len(valid) % 8; rem != 0 {
+				validMap.Buffer[len(validMap.Buffer)-1] |= ^byte(0) << rem
+			}
+			vecs[i] = vector.NumericVecFromComponents(dtype.Int32{}, make([]int32, len(valid)), validMap)
+			want = append(want, valid...)
+		}
+
+		got := concatValidity(vecs)
+		if got.TrueLen != len(want) {
+			t.Errorf("%s: TrueLen = %d; want %d", tt.name, got.TrueLen, len(want))
+		}
+		wantNulls := 0
+		for i, v := range want {
+			if !v {
+				wantNulls++
+			}
+			if bitAt(got.Buffer, i) != v {
+				t.Errorf("%s: bit %d = %v; want %v", tt.name, i, !v, v)
+			}
+		}
+		if got.NullCount != wantNulls {
+			t.Errorf("%s: NullCount = %d; want %d", tt.name, got.NullCount, wantNulls)
+		}
+		if recount := vector.NullCountFromByteBuff(got.Buffer, got.TrueLen); recount != wantNulls {
+			t.Errorf("%s: NullCount of buffer = %d; want %d", tt.name, recount, wantNulls)
+		}
+		for i := len(want); i < len(got.Buffer)*8; i++ {
+			if bitAt(got.Buffer, i) {
+				t.Errorf("%s: padding bit %d is set", tt.name, i)
+			}
+		}
+	}
+}
@@ -0,0 +1,53 @@
+package selop
+
+import (
+	"math/bits"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Filter returns a new vector, keeping the elements of v where mask is true.
+//
+// Null mask elements are treated as false; Filter assumes v and mask are of the same length.
+func Filter(v vector.Vector, mask *vector.BoolVector) (vector.Vector, error) {
+	return Take(v, MaskIndices(mask))
+}
+
+// MaskIndices returns the indices at which a BoolVector is true and not null
+func MaskIndices(mask *vector.BoolVector) []int {
+	data, valid := mask.Data(), mask.Validity().Buffer
+	chunkCounts := make([]int, compute.NumWorkers)
+
+	// first pass: count selected elements per chunk, a byte at a time
+	compute.ParallelChunks(mask.Len(), 8, func(w, start, end int) {
+		n := 0
+		for b := start / 8; b < (end+7)/8; b++ {
+			n += bits.OnesCount8(data[b] & valid[b])
+		}
+		chunkCounts[w] = n
+	})
+
+	// starting output index of each chunk
+	total := 0
+	for w, n := range chunkCounts {
+		chunkCounts[w] = total
+		total += n
+	}
+	indices := make([]int, total)
+
+	// second pass: write selected indices
+	compute.ParallelChunks(mask.Len(), 8, func(w, start, end int) {
+		out := chunkCounts[w]
+		for b := start / 8; b < (end+7)/8; b++ {
+			sel := data[b] & valid[b]
+			for sel != 0 {
+				indices[out] = b*8 + bits.TrailingZeros8(sel)
+				sel &= sel - 1
+				out++
+			}
+		}
+	})
+
+	return indices
+}
@@ -0,0 +1,161 @@
+package selop
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// IfElse returns a new vector, taking the element of a where mask is true, and of b otherwise.
+//
+// a and b must share a type; either may be of length 1, broadcasting a scalar. Null mask elements are
+// treated as false, as with Filter.
+func IfElse(mask *vector.BoolVector, a, b vector.Vector) (vector.Vector, error) {
+	n := mask.Len()
+	if a.Type().Type() != b.Type().Type() {
+		return nil, fmt.Errorf("mismatched types for IfElse: %v and %v", a.Type(), b.Type())
+	}
+	var err error
+	if a, err = broadcast(a, n); err != nil {
+		return nil, err
+	}
+	if b, err = broadcast(b, n); err != nil {
+		return nil, err
+	}
+
+	switch x := a.(type) {
+	case *vector.NumericVector[uint8]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[uint16]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[uint32]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[uint64]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[int8]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[int16]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[int32]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[int64]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[int]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[float32]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.NumericVector[float64]:
+		return ifElseNumeric(mask, x, b)
+	case *vector.DateVector:
+		y := b.(*vector.DateVector)
+		dataBuff, validity := ifElseFixed(mask, x.Data(), y.Data(), x.Validity(), y.Validity())
+		return vector.DateVecFromComponents(dataBuff, validity), nil
+	case *vector.BoolVector:
+		return ifElseBool(mask, x, b.(*vector.BoolVector)), nil
+	case *vector.StringVector:
+		return ifElseString(mask, x, b.(*vector.StringVector)), nil
+	}
+	return nil, fmt.Errorf("IfElse not supported for vector of type %v", a.Type())
+}
+
+// broadcast returns v if it has n elements, or v's single element repeated n times
+func broadcast(v vector.Vector, n int) (vector.Vector, error) {
+	switch v.Len() {
+	case n:
+		return v, nil
+	case 1:
+		return Take(v, make([]int, n))
+	}
+	return nil, fmt.Errorf("IfElse operand of length %d cannot be broadcast to length %d", v.Len(), n)
+}
+
+func ifElseNumeric[T vector.Numeric](mask *vector.BoolVector, x *vector.NumericVector[T], b vector.Vector) (vector.Vector, error) {
+	y, ok := b.(*vector.NumericVector[T])
+	if !ok {
+		return nil, fmt.Errorf("mismatched types for IfElse: %v and %v", x.Type(), b.Type())
+	}
+	dataBuff, validity := ifElseFixed(mask, x.Data(), y.Data(), x.Validity(), y.Validity())
+	return vector.NumericVecFromComponents(x.Type(), dataBuff, validity), nil
+}
+
+// ifElseFixed selects fixed-width data elements, and validity bits a byte (8 elements) at a time
+func ifElseFixed[T vector.Numeric](mask *vector.BoolVector, a, b []T, aValid, bValid vector.ValidityBitMap) ([]T, vector.ValidityBitMap) {
+	dataBuff := make([]T, len(a))
+	validBuff := make([]byte, aValid.Len())
+	maskData, maskValid := mask.Data(), mask.Validity().Buffer
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(len(a), 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			sel := maskData[i] & maskValid[i]
+			validBuff[i] = sel&aValid.Buffer[i] | ^sel&bValid.Buffer[i]
+		}
+		for i := start; i < end; i++ {
+			if maskData[i/8]&maskValid[i/8]&(1<<(i%8)) != 0 {
+				dataBuff[i] = a[i]
+			} else {
+				dataBuff[i] = b[i]
+			}
+		}
+	})
+
+	return dataBuff, vector.ValidityBitMapFromBuff(validBuff, len(a))
+}
+
+func ifElseBool(mask, x, y *vector.BoolVector) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validBuff := make([]byte, x.Validity().Len())
+	maskData, maskValid := mask.Data(), mask.Validity().Buffer
+	xData, yData := x.Data(), y.Data()
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			sel := maskData[i] & maskValid[i]
+			dataBuff[i] = sel&xData[i] | ^sel&yData[i]
+			validBuff[i] = sel&xValid[i] | ^sel&yValid[i]
+		}
+	})
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, vector.ValidityBitMapFromBuff(validBuff, x.Len()))
+}
+
+// ifElseString selects strings in two passes: the first sizes each output element, the second fills it
+func ifElseString(mask *vector.BoolVector, x, y *vector.StringVector) *vector.StringVector {
+	n := x.Len()
+	offsetsBuff := make([]int64, n+1)
+	validBuff := make([]byte, x.Validity().Len())
+	maskData, maskValid := mask.Data(), mask.Validity().Buffer
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+
+	pick := func(i int) *vector.StringVector {
+		if maskData[i/8]&maskValid[i/8]&(1<<(i%8)) != 0 {
+			return x
+		}
+		return y
+	}
+
+	compute.ParallelChunks(n, 8, func(_, start, end int) {
+		for i := start / 8; i < (end+7)/8; i++ {
+			sel := maskData[i] & maskValid[i]
+			validBuff[i] = sel&xValid[i] | ^sel&yValid[i]
+		}
+		for i := start; i < end; i++ {
+			offsetsBuff[i+1] = int64(len(pick(i).ValAt(i)))
+		}
+	})
+	for i := 0; i < n; i++ {
+		offsetsBuff[i+1] += offsetsBuff[i]
+	}
+	dataBuff := make([]byte, offsetsBuff[n])
+
+	compute.ParallelChunks(n, 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			copy(dataBuff[offsetsBuff[i]:offsetsBuff[i+1]], pick(i).ValAt(i))
+		}
+	})
+
+	return vector.StringVecFromComponents(dataBuff, offsetsBuff, vector.ValidityBitMapFromBuff(validBuff, n))
+}
@@ -0,0 +1,144 @@
+package selop
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Take returns a new vector, gathering the elements of v at the given indices, in order.
+//
+// Indices may repeat, and must be within the bounds of v; a negative index gives a null element.
+func Take(v vector.Vector, indices []int) (vector.Vector, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[uint16]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[uint32]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[uint64]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[int8]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[int16]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[int32]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[int64]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[int]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[float32]:
+		return takeNumeric(x, indices), nil
+	case *vector.NumericVector[float64]:
+		return takeNumeric(x, indices), nil
+	case *vector.StringVector:
+		return takeString(x, indices), nil
+	case *vector.DateVector:
+		return takeDate(x, indices), nil
+	case *vector.BoolVector:
+		return takeBool(x, indices), nil
+	}
+	return nil, fmt.Errorf("Take not supported for vector of type %v", v.Type())
+}
+
+func takeNumeric[T vector.Numeric](x *vector.NumericVector[T], indices []int) *vector.NumericVector[T] {
+	dataBuff, validity := takeFixed(x.Data(), x.Validity(), indices)
+	return vector.NumericVecFromComponents(x.Type(), dataBuff, validity)
+}
+
+func takeDate(x *vector.DateVector, indices []int) *vector.DateVector {
+	dataBuff, validity := takeFixed(x.Data(), x.Validity(), indices)
+	return vector.DateVecFromComponents(dataBuff, validity)
+}
+
+// takeFixed gathers a fixed-width data buffer and its validity bitmap
+func takeFixed[T vector.Numeric](data []T, valid vector.ValidityBitMap, indices []int) ([]T, vector.ValidityBitMap) {
+	dataBuff := make([]T, len(indices))
+	validBuff := make([]byte, (len(indices)+7)/8)
+
+	// chunks are divisible by 8; no two workers write to the same validity byte
+	compute.ParallelChunks(len(indices), 8, func(_, start, end int) {
+		for j := start; j < end; j++ {
+			if i := indices[j]; i >= 0 {
+				dataBuff[j] = data[i]
+			}
+		}
+		takeBits(validBuff, valid.Buffer, indices, start, end)
+	})
+
+	return dataBuff, vector.ValidityBitMapFromBuff(validBuff, len(indices))
+}
+
+func takeBool(x *vector.BoolVector, indices []int) *vector.BoolVector {
+	dataBuff := make([]byte, (len(indices)+7)/8)
+	validBuff := make([]byte, (len(indices)+7)/8)
+
+	compute.ParallelChunks(len(indices), 8, func(_, start, end int) {
+		takeBits(dataBuff, x.Data(), indices, start, end)
+		takeBits(validBuff, x.Validity().Buffer, indices, start, end)
+	})
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, vector.ValidityBitMapFromBuff(validBuff, len(indices)))
+}
+
+func takeString(x *vector.StringVector, indices []int) *vector.StringVector {
+	offsetsBuff := make([]int64, len(indices)+1)
+	validBuff := make([]byte, (len(indices)+7)/8)
+	chunkLenB := make([]int64, compute.NumWorkers)
+	xOffsets := x.Offsets()
+
+	// first pass: byte-length of each chunk
+	compute.ParallelChunks(len(indices), 8, func(w, start, end int) {
+		var n int64
+		for j := start; j < end; j++ {
+			if i := indices[j]; i >= 0 {
+				n += xOffsets[i+1] - xOffsets[i]
+			}
+		}
+		chunkLenB[w] = n
+	})
+
+	// starting byte of each chunk
+	var totalLenB int64
+	for w, n := range chunkLenB {
+		chunkLenB[w] = totalLenB
+		totalLenB += n
+	}
+	dataBuff := make([]byte, totalLenB)
+
+	// second pass: fill offsets, data and validity
+	compute.ParallelChunks(len(indices), 8, func(w, start, end int) {
+		off := chunkLenB[w]
+		for j := start; j < end; j++ {
+			offsetsBuff[j] = off
+			if i := indices[j]; i >= 0 {
+				val := x.ValAt(i)
+				copy(dataBuff[off:], val)
+				off += int64(len(val))
+			}
+		}
+		takeBits(validBuff, x.Validity().Buffer, indices, start, end)
+	})
+
+	// handle final offset element
+	offsetsBuff[len(offsetsBuff)-1] = totalLenB
+
+	return vector.StringVecFromComponents(dataBuff, offsetsBuff, vector.ValidityBitMapFromBuff(validBuff, len(indices)))
+}
+
+// takeBits gathers the bits of src at indices[start:end] into dst[start:end], leaving bits of negative
+// indices unset; start must be divisible by 8
+func takeBits(dst, src []byte, indices []int, start, end int) {
+	for j := start; j < end; j++ {
+		i := indices[j]
+		if i < 0 {
+			continue
+		}
+		bit := (src[i/8] >> (i % 8)) & 1
+		dst[j/8] |= bit << (j % 8)
+	}
+}
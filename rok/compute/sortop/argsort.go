@@ -0,0 +1,247 @@
+package sortop
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// ArgSort returns the row indices of v in stably sorted order.
+//
+// Null elements come first, or last if nullsLast is set, regardless of descending. Integers and dates are
+// radix sorted; floats are radix sorted with NaN greater than all other values (so NaN comes last, or
+// first when descending); strings compare bytewise, and false is less than true.
+func ArgSort(v vector.Vector, descending, nullsLast bool) ([]int, error) {
+	return ArgSortMulti([]vector.Vector{v}, []bool{descending}, nullsLast)
+}
+
+// ArgSortMulti returns the row indices of the key vectors in stably sorted order, sorting by the first key,
+// then by each following key among equal rows (see ArgSort).
+//
+// descending holds the sort direction of each key; an empty slice sorts all keys in ascending order.
+func ArgSortMulti(keys []vector.Vector, descending []bool, nullsLast bool) ([]int, error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one sort key is required")
+	}
+	if len(descending) != 0 && len(descending) != len(keys) {
+		return nil, fmt.Errorf("got %d sort directions for %d keys", len(descending), len(keys))
+	}
+	n := keys[0].Len()
+	for _, k := range keys[1:] {
+		if k.Len() != n {
+			return nil, fmt.Errorf("mismatched sort key lengths %d and %d", n, k.Len())
+		}
+	}
+
+	perm := make([]int, n)
+	for i := range perm {
+		perm[i] = i
+	}
+	// stable sorts from the last key to the first leave rows ordered by all keys
+	for k := len(keys) - 1; k >= 0; k-- {
+		desc := len(descending) != 0 && descending[k]
+		if err := sortPerm(perm, keys[k], desc, nullsLast); err != nil {
+			return nil, err
+		}
+	}
+	return perm, nil
+}
+
+// sortPerm stably sorts perm, a permutation of v's row indices, by the elements of v
+func sortPerm(perm []int, v vector.Vector, descending, nullsLast bool) error {
+	nonNull, nulls := partitionNulls(perm, v)
+
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[uint16]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[uint32]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[uint64]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[int8]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[int16]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[int32]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[int64]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[int]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[float32]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.NumericVector[float64]:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+	case *vector.DateVector:
+		radixSort(nonNull, fixedKeys(x.Data(), nonNull, descending))
+
+	case *vector.BoolVector:
+		// false before true, or true before false when descending
+		first, second := partition(nonNull, func(i int) bool { return x.ValAt(i) == descending })
+		copy(nonNull, append(first, second...))
+
+	case *vector.StringVector:
+		sign := 1
+		if descending {
+			sign = -1
+		}
+		mergeSort(nonNull, func(i, j int) int {
+			return sign * bytes.Compare(x.ValAt(i), x.ValAt(j))
+		})
+
+	default:
+		return fmt.Errorf("ArgSort not supported for vector of type %v", v.Type())
+	}
+
+	if nullsLast {
+		copy(perm, nonNull)
+		copy(perm[len(nonNull):], nulls)
+	} else {
+		copy(perm[len(nulls):], nonNull)
+		copy(perm, nulls)
+	}
+	return nil
+}
+
+// partitionNulls stably splits perm into the rows where v is non-null, and the rows where v is null
+func partitionNulls(perm []int, v vector.Vector) ([]int, []int) {
+	if v.NullCount() == 0 {
+		return slices.Clone(perm), nil
+	}
+	return partition(perm, func(i int) bool { return !v.IsNull(i) })
+}
+
+// partition stably splits rows into those satisfying pred, and those that don't
+func partition(rows []int, pred func(i int) bool) ([]int, []int) {
+	var yes, no []int
+	for _, i := range rows {
+		if pred(i) {
+			yes = append(yes, i)
+		} else {
+			no = append(no, i)
+		}
+	}
+	return yes, no
+}
+
+// fixedKeys returns the unsigned radix key of data[i] for each row i of rows, in parallel; keys sort in the
+// same order as the elements, or the reverse order when descending
+func fixedKeys[T vector.Numeric](data []T, rows []int, descending bool) []uint64 {
+	keys := make([]uint64, len(rows))
+	float, signed := compute.IsFloat[T](), compute.IsSigned[T]()
+
+	compute.ParallelChunks(len(rows), 1, func(_, start, end int) {
+		for j := start; j < end; j++ {
+			x := data[rows[j]]
+			var k uint64
+			switch {
+			case float:
+				k = floatKey(float64(x))
+			case signed:
+				// flipping the sign bit orders negatives before positives
+				k = uint64(int64(x)) ^ (1 << 63)
+			default:
+				k = uint64(x)
+			}
+			if descending {
+				k = ^k
+			}
+			keys[j] = k
+		}
+	})
+	return keys
+}
+
+// floatKey returns an unsigned key ordering floats by value, with all NaN values equal to each other and
+// greater than +Inf; -0 is equal to 0
+func floatKey(f float64) uint64 {
+	switch {
+	case f != f:
+		return math.MaxUint64
+	case f == 0:
+		f = 0
+	}
+	b := math.Float64bits(f)
+	// negatives have all bits flipped to reverse their order; positives have the sign bit set
+	if b>>63 == 1 {
+		return ^b
+	}
+	return b | 1<<63
+}
+
+// radixSort stably sorts rows by their keys, with a least significant digit radix sort over bytes;
+// passes over bytes that are equal across all keys are skipped
+func radixSort(rows []int, keys []uint64) {
+	n := len(rows)
+	tmpRows, tmpKeys := make([]int, n), make([]uint64, n)
+
+	for shift := 0; shift < 64; shift += 8 {
+		var counts [256]int
+		for _, k := range keys {
+			counts[(k>>shift)&0xff]++
+		}
+		if n == 0 || counts[(keys[0]>>shift)&0xff] == n {
+			continue
+		}
+
+		// starting position of each digit
+		var pos int
+		for d, c := range counts {
+			counts[d] = pos
+			pos += c
+		}
+		for j, k := range keys {
+			d := (k >> shift) & 0xff
+			tmpRows[counts[d]], tmpKeys[counts[d]] = rows[j], k
+			counts[d]++
+		}
+		copy(rows, tmpRows)
+		keys, tmpKeys = tmpKeys, keys
+	}
+}
+
+// mergeSort stably sorts rows with cmp; chunks are sorted in parallel, then merged
+func mergeSort(rows []int, cmp func(i, j int) int) {
+	bounds := make([]int, compute.NumWorkers+1)
+	compute.ParallelChunks(len(rows), 1, func(w, start, end int) {
+		slices.SortStableFunc(rows[start:end], cmp)
+		bounds[w+1] = end
+	})
+
+	// merge adjacent sorted runs until one remains
+	tmp := make([]int, len(rows))
+	for len(bounds) > 2 {
+		next := []int{0}
+		for r := 0; r+1 < len(bounds); r += 2 {
+			if r+2 >= len(bounds) {
+				next = append(next, bounds[r+1])
+				break
+			}
+			lo, mid, hi := bounds[r], bounds[r+1], bounds[r+2]
+			merge(tmp[lo:hi], rows[lo:mid], rows[mid:hi], cmp)
+			copy(rows[lo:hi], tmp[lo:hi])
+			next = append(next, hi)
+		}
+		bounds = next
+	}
+}
+
+// merge merges the sorted runs a and b into out; on ties, elements of a come first
+func merge(out, a, b []int, cmp func(i, j int) int) {
+	var i, j int
+	for k := range out {
+		if j == len(b) || (i < len(a) && cmp(a[i], b[j]) <= 0) {
+			out[k] = a[i]
+			i++
+		} else {
+			out[k] = b[j]
+			j++
+		}
+	}
+}
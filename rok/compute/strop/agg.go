@@ -0,0 +1,109 @@
+package strop
+
+import (
+	"bytes"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Min returns the bytewise minimum of the non-null elements of x; returns false if x has no non-null elements.
+//
+// The returned slice shares memory with x.
+func Min(x *vector.StringVector) ([]byte, bool) {
+	return extremum(x, -1)
+}
+
+// Max returns the bytewise maximum of the non-null elements of x; returns false if x has no non-null elements.
+//
+// The returned slice shares memory with x.
+func Max(x *vector.StringVector) ([]byte, bool) {
+	return extremum(x, 1)
+}
+
+// extremum returns the non-null element of x whose bytes.Compare result against all others is `sign` or 0
+func extremum(x *vector.StringVector, sign int) ([]byte, bool) {
+	// index of each worker's partial extremum, or -1
+	parts := make([]int, compute.NumWorkers)
+	hasNulls := x.NullCount() > 0
+
+	compute.ParallelChunks(x.Len(), 8, func(w, start, end int) {
+		best := -1
+		for i := start; i < end; i++ {
+			if hasNulls && x.IsNull(i) {
+				continue
+			}
+			if best == -1 || bytes.Compare(x.ValAt(i), x.ValAt(best)) == sign {
+				best = i
+			}
+		}
+		parts[w] = best
+	})
+
+	best := -1
+	for _, i := range parts {
+		if i != -1 && (best == -1 || bytes.Compare(x.ValAt(i), x.ValAt(best)) == sign) {
+			best = i
+		}
+	}
+	if best == -1 {
+		return nil, false
+	}
+	return x.ValAt(best), true
+}
+
+// GroupMin returns the bytewise minimum of the non-null elements of x in each group; a group with no
+// non-null elements is null
+func GroupMin(x *vector.StringVector, g *compute.Groups) *vector.StringVector {
+	return groupExtremum(x, g, -1)
+}
+
+// GroupMax returns the bytewise maximum of the non-null elements of x in each group; a group with no
+// non-null elements is null
+func GroupMax(x *vector.StringVector, g *compute.Groups) *vector.StringVector {
+	return groupExtremum(x, g, 1)
+}
+
+func groupExtremum(x *vector.StringVector, g *compute.Groups, sign int) *vector.StringVector {
+	// row index of each group's extremum, or -1
+	best := make([]int, g.Len())
+	offsetsBuff := make([]int64, g.Len()+1)
+	validBuff := make([]byte, (g.Len()+7)/8)
+
+	// first pass: find each group's extremum, and its length in bytes
+	compute.ParallelChunks(g.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			b := -1
+			for _, r := range g.GroupRows(i) {
+				if !x.IsNull(r) && (b == -1 || bytes.Compare(x.ValAt(r), x.ValAt(b)) == sign) {
+					b = r
+				}
+			}
+			best[i] = b
+			if b != -1 {
+				offsetsBuff[i+1] = int64(len(x.ValAt(b)))
+				validBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+	})
+	for i := 0; i < g.Len(); i++ {
+		offsetsBuff[i+1] += offsetsBuff[i]
+	}
+
+	// second pass: fill
+	dataBuff := make([]byte, offsetsBuff[g.Len()])
+	compute.ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if best[i] != -1 {
+				copy(dataBuff[offsetsBuff[i]:], x.ValAt(best[i]))
+			}
+		}
+	})
+
+	validMap := vector.ValidityBitMap{
+		TrueLen:   g.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, g.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.StringVecFromComponents(dataBuff, offsetsBuff, validMap)
+}
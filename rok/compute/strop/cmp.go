@@ -0,0 +1,142 @@
+package strop
+
+import (
+	"bytes"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// EqVec returns a BoolVector, evaluating whether each element of x is bytewise equal to the corresponding element of y
+func EqVec(x, y *vector.StringVector) *vector.BoolVector {
+	return cmpVec(x, y, eqBytes)
+}
+
+// NeVec returns a BoolVector, evaluating whether each element of x is bytewise not equal to the corresponding element of y
+func NeVec(x, y *vector.StringVector) *vector.BoolVector {
+	return cmpVec(x, y, neBytes)
+}
+
+// LtVec returns a BoolVector, evaluating whether each element of x is bytewise less than the corresponding element of y
+func LtVec(x, y *vector.StringVector) *vector.BoolVector {
+	return cmpVec(x, y, ltBytes)
+}
+
+// LeVec returns a BoolVector, evaluating whether each element of x is bytewise less than or equal to the corresponding element of y
+func LeVec(x, y *vector.StringVector) *vector.BoolVector {
+	return cmpVec(x, y, leBytes)
+}
+
+// GtVec returns a BoolVector, evaluating whether each element of x is bytewise greater than the corresponding element of y
+func GtVec(x, y *vector.StringVector) *vector.BoolVector {
+	return cmpVec(x, y, gtBytes)
+}
+
+// GeVec returns a BoolVector, evaluating whether each element of x is bytewise greater than or equal to the corresponding element of y
+func GeVec(x, y *vector.StringVector) *vector.BoolVector {
+	return cmpVec(x, y, geBytes)
+}
+
+// EqLit returns a BoolVector, evaluating whether each element of x is bytewise equal to a string literal (as byte slice input)
+func EqLit(x *vector.StringVector, lit []byte) *vector.BoolVector {
+	return cmpLit(x, lit, eqBytes)
+}
+
+// NeLit returns a BoolVector, evaluating whether each element of x is bytewise not equal to a string literal (as byte slice input)
+func NeLit(x *vector.StringVector, lit []byte) *vector.BoolVector {
+	return cmpLit(x, lit, neBytes)
+}
+
+// LtLit returns a BoolVector, evaluating whether each element of x is bytewise less than a string literal (as byte slice input)
+func LtLit(x *vector.StringVector, lit []byte) *vector.BoolVector {
+	return cmpLit(x, lit, ltBytes)
+}
+
+// LeLit returns a BoolVector, evaluating whether each element of x is bytewise less than or equal to a string literal (as byte slice input)
+func LeLit(x *vector.StringVector, lit []byte) *vector.BoolVector {
+	return cmpLit(x, lit, leBytes)
+}
+
+// GtLit returns a BoolVector, evaluating whether each element of x is bytewise greater than a string literal (as byte slice input)
+func GtLit(x *vector.StringVector, lit []byte) *vector.BoolVector {
+	return cmpLit(x, lit, gtBytes)
+}
+
+// GeLit returns a BoolVector, evaluating whether each element of x is bytewise greater than or equal to a string literal (as byte slice input)
+func GeLit(x *vector.StringVector, lit []byte) *vector.BoolVector {
+	return cmpLit(x, lit, geBytes)
+}
+
+// cmpVec performs the comparison on two StringVectors, returning a resulting BoolVector
+func cmpVec(x, y *vector.StringVector, cmpFn func(a, b []byte) bool) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validBuff := make([]byte, x.Validity().Len())
+
+	xValid, yValid := x.Validity().Buffer, y.Validity().Buffer
+
+	// chunks are divisible by 8; each worker owns whole bytes of the output bitmaps
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if cmpFn(x.ValAt(i), y.ValAt(i)) {
+				dataBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+		// bitwise AND to get new nulls; null slots are cleared to false
+		for i := start / 8; i < (end+7)/8; i++ {
+			validBuff[i] = xValid[i] & yValid[i]
+			dataBuff[i] &= validBuff[i]
+		}
+	})
+
+	validity := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity)
+}
+
+// cmpLit performs the comparison between a StringVector and literal, returning a resulting BoolVector
+func cmpLit(x *vector.StringVector, lit []byte, cmpFn func(a, b []byte) bool) *vector.BoolVector {
+	dataBuff := make([]byte, x.Validity().Len())
+	validity := x.Validity().DeepCopy()
+
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if cmpFn(x.ValAt(i), lit) {
+				dataBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+		// null slots are cleared to false
+		for i := start / 8; i < (end+7)/8; i++ {
+			dataBuff[i] &= validity.Buffer[i]
+		}
+	})
+
+	return vector.BoolVecFromComponenets(dtype.Bool{}, dataBuff, validity)
+}
+
+func eqBytes(a, b []byte) bool {
+	return bytes.Equal(a, b)
+}
+
+func neBytes(a, b []byte) bool {
+	return !bytes.Equal(a, b)
+}
+
+func ltBytes(a, b []byte) bool {
+	return bytes.Compare(a, b) < 0
+}
+
+func leBytes(a, b []byte) bool {
+	return bytes.Compare(a, b) <= 0
+}
+
+func gtBytes(a, b []byte) bool {
+	return bytes.Compare(a, b) > 0
+}
+
+func geBytes(a, b []byte) bool {
+	return bytes.Compare(a, b) >= 0
+}
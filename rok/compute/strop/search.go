@@ -0,0 +1,85 @@
+package strop
+
+import (
+	"bytes"
+	"regexp"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Literal searches use the bytes package, which combines vectorized first-byte scans with Rabin-Karp;
+// compiled regexes are safe for concurrent use, so one is shared by all workers.
+
+// Contains returns a BoolVector, evaluating whether each element of x contains sub
+func Contains(x *vector.StringVector, sub []byte) *vector.BoolVector {
+	return cmpLit(x, sub, bytes.Contains)
+}
+
+// StartsWith returns a BoolVector, evaluating whether each element of x begins with prefix
+func StartsWith(x *vector.StringVector, prefix []byte) *vector.BoolVector {
+	return cmpLit(x, prefix, bytes.HasPrefix)
+}
+
+// EndsWith returns a BoolVector, evaluating whether each element of x ends with suffix
+func EndsWith(x *vector.StringVector, suffix []byte) *vector.BoolVector {
+	return cmpLit(x, suffix, bytes.HasSuffix)
+}
+
+// MatchRegex returns a BoolVector, evaluating whether each element of x contains a match of re
+func MatchRegex(x *vector.StringVector, re *regexp.Regexp) *vector.BoolVector {
+	return cmpLit(x, nil, func(a, _ []byte) bool { return re.Match(a) })
+}
+
+// Find returns the byte index of the first instance of sub in each element of x, as an int64 vector;
+// elements not containing sub are null
+func Find(x *vector.StringVector, sub []byte) *vector.NumericVector[int64] {
+	return searchInt(x, func(s []byte) (int64, bool) {
+		i := bytes.Index(s, sub)
+		return int64(i), i >= 0
+	})
+}
+
+// CountMatches returns the number of non-overlapping instances of sub in each element of x, as an int64
+// vector; an empty sub matches before and after each UTF-8 code point, as with bytes.Count
+func CountMatches(x *vector.StringVector, sub []byte) *vector.NumericVector[int64] {
+	return searchInt(x, func(s []byte) (int64, bool) {
+		return int64(bytes.Count(s, sub)), true
+	})
+}
+
+// CountMatchesRegex returns the number of non-overlapping matches of re in each element of x, as an int64 vector
+func CountMatchesRegex(x *vector.StringVector, re *regexp.Regexp) *vector.NumericVector[int64] {
+	return searchInt(x, func(s []byte) (int64, bool) {
+		return int64(len(re.FindAllIndex(s, -1))), true
+	})
+}
+
+// searchInt evaluates fn on each non-null element of x, returning an int64 vector; elements where fn
+// returns false are null
+func searchInt(x *vector.StringVector, fn func(s []byte) (int64, bool)) *vector.NumericVector[int64] {
+	dataBuff := make([]int64, x.Len())
+	validBuff := make([]byte, x.Validity().Len())
+	xValid := x.Validity()
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if xValid.IsNull(i) {
+				continue
+			}
+			if v, ok := fn(x.ValAt(i)); ok {
+				dataBuff[i] = v
+				validBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+	})
+
+	validity := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.NumericVecFromComponents(dtype.Int64{}, dataBuff, validity)
+}
@@ -0,0 +1,345 @@
+package strop
+
+import (
+	"bytes"
+	"regexp"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Replace replaces the first instance of old with new, in each element of x
+func Replace(x *vector.StringVector, old, new []byte) *vector.StringVector {
+	return replaceLit(x, old, new, 1)
+}
+
+// ReplaceAll replaces all non-overlapping instances of old with new, in each element of x; an empty old
+// matches before and after each UTF-8 code point, as with bytes.ReplaceAll
+func ReplaceAll(x *vector.StringVector, old, new []byte) *vector.StringVector {
+	return replaceLit(x, old, new, -1)
+}
+
+// ReplaceRegex replaces the first match of re with repl, in each element of x; repl may refer to
+// submatches, as with regexp.Regexp.Expand
+func ReplaceRegex(x *vector.StringVector, re *regexp.Regexp, repl []byte) *vector.StringVector {
+	return mapStringsAppend(x, func(dst, s []byte) []byte {
+		m := re.FindSubmatchIndex(s)
+		if m == nil {
+			return append(dst, s...)
+		}
+		dst = append(dst, s[:m[0]]...)
+		dst = re.Expand(dst, repl, s, m)
+		return append(dst, s[m[1]:]...)
+	})
+}
+
+// ReplaceAllRegex replaces all matches of re with repl, in each element of x; repl may refer to
+// submatches, as with regexp.Regexp.ReplaceAll
+func ReplaceAllRegex(x *vector.StringVector, re *regexp.Regexp, repl []byte) *vector.StringVector {
+	return mapStringsAppend(x, func(dst, s []byte) []byte {
+		return append(dst, re.ReplaceAll(s, repl)...)
+	})
+}
+
+// Slice returns the substring of up to length runes of each element of x, beginning at rune start;
+// a negative start counts back from the end of each element, and a negative length takes all remaining runes
+func Slice(x *vector.StringVector, start, length int) *vector.StringVector {
+	return mapStrings(x, func(s []byte) int {
+		lo, hi := runeRange(s, start, length)
+		return hi - lo
+	}, func(dst, s []byte) {
+		lo, hi := runeRange(s, start, length)
+		copy(dst, s[lo:hi])
+	})
+}
+
+// SplitN splits each element of x around instances of sep, into n StringVectors: the i-th holds the
+// i-th part of each element, and the last holds the unsplit remainder. Elements with fewer than i+1
+// parts are null in the i-th StringVector.
+func SplitN(x *vector.StringVector, sep []byte, n int) []*vector.StringVector {
+	parts := make([]*vector.StringVector, n)
+	for i := range parts {
+		parts[i] = SplitPart(x, sep, i, n)
+	}
+	return parts
+}
+
+// SplitPart returns the i-th StringVector of SplitN(x, sep, n)
+func SplitPart(x *vector.StringVector, sep []byte, i, n int) *vector.StringVector {
+	return mapStringsNullable(x, func(s []byte) (int, bool) {
+		lo, hi, ok := splitPart(s, sep, i, n)
+		return hi - lo, ok
+	}, func(dst, s []byte) {
+		lo, hi, _ := splitPart(s, sep, i, n)
+		copy(dst, s[lo:hi])
+	})
+}
+
+// Trim removes all leading and trailing runes contained in cutset from each element of x; an empty
+// cutset removes white space, as defined by Unicode
+func Trim(x *vector.StringVector, cutset string) *vector.StringVector {
+	if cutset == "" {
+		return trim(x, bytes.TrimSpace)
+	}
+	return trim(x, func(s []byte) []byte { return bytes.Trim(s, cutset) })
+}
+
+// TrimLeft removes all leading runes contained in cutset from each element of x (see Trim)
+func TrimLeft(x *vector.StringVector, cutset string) *vector.StringVector {
+	if cutset == "" {
+		return trim(x, func(s []byte) []byte { return bytes.TrimLeftFunc(s, unicode.IsSpace) })
+	}
+	return trim(x, func(s []byte) []byte { return bytes.TrimLeft(s, cutset) })
+}
+
+// TrimRight removes all trailing runes contained in cutset from each element of x (see Trim)
+func TrimRight(x *vector.StringVector, cutset string) *vector.StringVector {
+	if cutset == "" {
+		return trim(x, func(s []byte) []byte { return bytes.TrimRightFunc(s, unicode.IsSpace) })
+	}
+	return trim(x, func(s []byte) []byte { return bytes.TrimRight(s, cutset) })
+}
+
+// PadLeft pads the start of each element of x with fill, to a length of width runes; longer elements are unchanged
+func PadLeft(x *vector.StringVector, width int, fill rune) *vector.StringVector {
+	return pad(x, width, fill, true)
+}
+
+// PadRight pads the end of each element of x with fill, to a length of width runes; longer elements are unchanged
+func PadRight(x *vector.StringVector, width int, fill rune) *vector.StringVector {
+	return pad(x, width, fill, false)
+}
+
+// Reverse reverses the runes of each element of x; invalid UTF-8 bytes are reversed as single runes
+func Reverse(x *vector.StringVector) *vector.StringVector {
+	return mapStrings(x, func(s []byte) int {
+		return len(s)
+	}, func(dst, s []byte) {
+		end := len(dst)
+		for len(s) > 0 {
+			_, size := utf8.DecodeRune(s)
+			copy(dst[end-size:end], s[:size])
+			end -= size
+			s = s[size:]
+		}
+	})
+}
+
+// LenBytes returns the length in bytes of each element of x, as an int32 vector
+func LenBytes(x *vector.StringVector) *vector.NumericVector[int32] {
+	return lengths(x, func(s []byte) int32 { return int32(len(s)) })
+}
+
+// LenChars returns the length in runes of each element of x, as an int32 vector
+func LenChars(x *vector.StringVector) *vector.NumericVector[int32] {
+	return lengths(x, func(s []byte) int32 { return int32(utf8.RuneCount(s)) })
+}
+
+func replaceLit(x *vector.StringVector, old, new []byte, limit int) *vector.StringVector {
+	count := func(s []byte) int {
+		n := bytes.Count(s, old)
+		if limit >= 0 {
+			n = min(n, limit)
+		}
+		return n
+	}
+	return mapStrings(x, func(s []byte) int {
+		return len(s) + count(s)*(len(new)-len(old))
+	}, func(dst, s []byte) {
+		// follows bytes.Replace, appending into dst's exactly sized space
+		dst, start := dst[:0], 0
+		for i := range count(s) {
+			j := start
+			if len(old) == 0 {
+				if i > 0 {
+					_, size := utf8.DecodeRune(s[start:])
+					j += size
+				}
+			} else {
+				j += bytes.Index(s[start:], old)
+			}
+			dst = append(dst, s[start:j]...)
+			dst = append(dst, new...)
+			start = j + len(old)
+		}
+		_ = append(dst, s[start:]...)
+	})
+}
+
+// runeRange returns the byte range of s spanning up to length runes from rune start (see Slice)
+func runeRange(s []byte, start, length int) (int, int) {
+	if start < 0 {
+		start = max(utf8.RuneCount(s)+start, 0)
+	}
+	lo := 0
+	for ; start > 0 && lo < len(s); start-- {
+		_, size := utf8.DecodeRune(s[lo:])
+		lo += size
+	}
+	if length < 0 {
+		return lo, len(s)
+	}
+	hi := lo
+	for ; length > 0 && hi < len(s); length-- {
+		_, size := utf8.DecodeRune(s[hi:])
+		hi += size
+	}
+	return lo, hi
+}
+
+// splitPart returns the byte range of part i of s, split around sep into at most n parts; an empty
+// sep splits after each UTF-8 code point, as with bytes.SplitN
+func splitPart(s, sep []byte, i, n int) (int, int, bool) {
+	lo := 0
+	for part := 0; ; part++ {
+		if len(sep) == 0 && lo == len(s) {
+			return 0, 0, false
+		}
+		if part == n-1 {
+			return lo, len(s), part == i
+		}
+		var j, next int
+		if len(sep) == 0 {
+			_, size := utf8.DecodeRune(s[lo:])
+			j, next = lo+size, lo+size
+		} else {
+			k := bytes.Index(s[lo:], sep)
+			if k < 0 {
+				return lo, len(s), part == i
+			}
+			j, next = lo+k, lo+k+len(sep)
+		}
+		if part == i {
+			return lo, j, true
+		}
+		lo = next
+	}
+}
+
+func trim(x *vector.StringVector, trimFn func(s []byte) []byte) *vector.StringVector {
+	return mapStrings(x, func(s []byte) int {
+		return len(trimFn(s))
+	}, func(dst, s []byte) {
+		copy(dst, trimFn(s))
+	})
+}
+
+func pad(x *vector.StringVector, width int, fill rune, left bool) *vector.StringVector {
+	fillBytes := utf8.AppendRune(nil, fill)
+	padLen := func(s []byte) int {
+		return max(width-utf8.RuneCount(s), 0) * len(fillBytes)
+	}
+	return mapStrings(x, func(s []byte) int {
+		return len(s) + padLen(s)
+	}, func(dst, s []byte) {
+		n := padLen(s)
+		padding, text := dst[:n], dst[n:]
+		if !left {
+			text, padding = dst[:len(s)], dst[len(s):]
+		}
+		copy(text, s)
+		for i := 0; i < n; i += len(fillBytes) {
+			copy(padding[i:], fillBytes)
+		}
+	})
+}
+
+func lengths(x *vector.StringVector, lenFn func(s []byte) int32) *vector.NumericVector[int32] {
+	dataBuff := make([]int32, x.Len())
+	compute.ParallelChunks(x.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			dataBuff[i] = lenFn(x.ValAt(i))
+		}
+	})
+	return vector.NumericVecFromComponents(dtype.Int32{}, dataBuff, x.Validity().DeepCopy())
+}
+
+// mapStrings returns a new StringVector, mapping each non-null element of x in two passes: the first
+// sizes each output element, the second fills it in place. Null elements stay null, and empty.
+func mapStrings(x *vector.StringVector, size func(s []byte) int, fill func(dst, s []byte)) *vector.StringVector {
+	return mapStringsNullable(x, func(s []byte) (int, bool) { return size(s), true }, fill)
+}
+
+// mapStringsNullable is mapStrings, where elements for which size returns false become null
+func mapStringsNullable(x *vector.StringVector, size func(s []byte) (int, bool), fill func(dst, s []byte)) *vector.StringVector {
+	n := x.Len()
+	newOffsetsBuffer := make([]int64, n+1)
+	validBuff := make([]byte, x.Validity().Len())
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(n, 8, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			if x.IsNull(i) {
+				continue
+			}
+			if lenB, ok := size(x.ValAt(i)); ok {
+				newOffsetsBuffer[i+1] = int64(lenB)
+				validBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+	})
+	for i := 0; i < n; i++ {
+		newOffsetsBuffer[i+1] += newOffsetsBuffer[i]
+	}
+	newDataBuffer := make([]byte, newOffsetsBuffer[n])
+
+	compute.ParallelChunks(n, 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			lo, hi := newOffsetsBuffer[i], newOffsetsBuffer[i+1]
+			if validBuff[i/8]&(1<<(i%8)) != 0 {
+				fill(newDataBuffer[lo:hi:hi], x.ValAt(i))
+			}
+		}
+	})
+
+	validity := vector.ValidityBitMap{
+		TrueLen:   n,
+		NullCount: vector.NullCountFromByteBuff(validBuff, n),
+		Buffer:    validBuff,
+	}
+	return vector.StringVecFromComponents(newDataBuffer, newOffsetsBuffer, validity)
+}
+
+// mapStringsAppend returns a new StringVector, mapping each non-null element of x by appending its output
+// to a buffer; for mappings that cannot be sized without being computed, such as regex replacements.
+// Each worker fills its own buffer, and buffers are then copied into place.
+func mapStringsAppend(x *vector.StringVector, appendFn func(dst, s []byte) []byte) *vector.StringVector {
+	n := x.Len()
+	newOffsetsBuffer := make([]int64, n+1)
+	chunkData := make([][]byte, compute.NumWorkers)
+
+	compute.ParallelChunks(n, 1, func(w, start, end int) {
+		var buf []byte
+		for i := start; i < end; i++ {
+			newOffsetsBuffer[i] = int64(len(buf))
+			if !x.IsNull(i) {
+				buf = appendFn(buf, x.ValAt(i))
+			}
+		}
+		chunkData[w] = buf
+	})
+
+	// starting byte of each chunk
+	chunkStart := make([]int64, compute.NumWorkers)
+	var totalLenB int64
+	for w, buf := range chunkData {
+		chunkStart[w] = totalLenB
+		totalLenB += int64(len(buf))
+	}
+	newDataBuffer := make([]byte, totalLenB)
+
+	compute.ParallelChunks(n, 1, func(w, start, end int) {
+		copy(newDataBuffer[chunkStart[w]:], chunkData[w])
+		for i := start; i < end; i++ {
+			newOffsetsBuffer[i] += chunkStart[w]
+		}
+	})
+
+	// handle final offset element
+	newOffsetsBuffer[n] = totalLenB
+
+	return vector.StringVecFromComponents(newDataBuffer, newOffsetsBuffer, x.Validity().DeepCopy())
+}
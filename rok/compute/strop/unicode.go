@@ -0,0 +1,192 @@
+package strop
+
+import (
+	"encoding/binary"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// caseKind represents a Unicode case conversion
+type caseKind int
+
+const (
+	caseUpper caseKind = iota
+	caseLower
+	caseTitle
+	caseFold
+)
+
+// specialCase holds the full (multi-rune) case mappings that unicode's simple mappings leave untouched,
+// indexed by caseKind; lower-case mappings are all simple
+var specialCase = map[rune][4]string{
+	'ß': {"SS", "ß", "Ss", "ss"},
+	'ẞ': {"ẞ", "ß", "ẞ", "ss"},
+	'ﬀ': {"FF", "ﬀ", "Ff", "ff"},
+	'ﬁ': {"FI", "ﬁ", "Fi", "fi"},
+	'ﬂ': {"FL", "ﬂ", "Fl", "fl"},
+	'ﬃ': {"FFI", "ﬃ", "Ffi", "ffi"},
+	'ﬄ': {"FFL", "ﬄ", "Ffl", "ffl"},
+	'ﬅ': {"ST", "ﬅ", "St", "st"},
+	'ﬆ': {"ST", "ﬆ", "St", "st"},
+}
+
+// ToUpper converts elements in a StringVector to upper-case, following Unicode; e.g. "straße" becomes "STRASSE"
+func ToUpper(x *vector.StringVector) *vector.StringVector {
+	return changeCase(x, caseUpper, toUpperASCII)
+}
+
+// ToLower converts elements in a StringVector to lower-case, following Unicode
+func ToLower(x *vector.StringVector) *vector.StringVector {
+	return changeCase(x, caseLower, toLowerASCII)
+}
+
+// ToTitle converts elements in a StringVector to title-case, following Unicode: the first letter of each
+// word is converted to title-case, and the rest to lower-case. Words are runs of letters, marks, digits
+// and apostrophes, so that "o'neil" becomes "O'neil".
+func ToTitle(x *vector.StringVector) *vector.StringVector {
+	return changeCase(x, caseTitle, toTitleWordsASCII)
+}
+
+// CaseFold folds the case of elements in a StringVector, such that strings equal under Unicode
+// case-insensitive comparison have equal folded forms; e.g. "STRASSE" and "straße" both fold to "strasse"
+func CaseFold(x *vector.StringVector) *vector.StringVector {
+	return changeCase(x, caseFold, toLowerASCII)
+}
+
+// changeCase converts the case of each element of x. If x's bytes are all ASCII, it falls back to the
+// in-place asciiFn; otherwise, conversions may change byte lengths, so offsets are recomputed, with
+// ASCII elements still converted in place.
+func changeCase(x *vector.StringVector, kind caseKind, asciiFn func(x *vector.StringVector, start, stop int)) *vector.StringVector {
+	chunkASCII := make([]bool, compute.NumWorkers)
+	compute.ParallelChunks(x.Len(), 1, func(w, start, end int) {
+		chunkASCII[w] = isASCII(x.Data()[x.Offsets()[start]:x.Offsets()[end]])
+	})
+	allASCII := true
+	for _, ok := range chunkASCII {
+		allASCII = allASCII && ok
+	}
+	if allASCII {
+		return changeCaseASCII(x, asciiFn)
+	}
+
+	return mapStringsAppend(x, func(dst, s []byte) []byte {
+		if isASCII(s) {
+			start := len(dst)
+			dst = append(dst, s...)
+			changeCaseASCIIBytes(dst[start:], kind)
+			return dst
+		}
+		return appendCase(dst, s, kind)
+	})
+}
+
+// appendCase appends s to dst, converting the case of each rune
+func appendCase(dst, s []byte, kind caseKind) []byte {
+	inWord, afterLetter := false, false
+	for len(s) > 0 {
+		r, size := utf8.DecodeRune(s)
+		if r == utf8.RuneError && size == 1 {
+			// keep invalid bytes as they are
+			dst = append(dst, s[0])
+			s = s[1:]
+			inWord, afterLetter = false, false
+			continue
+		}
+		s = s[size:]
+
+		// a capital sigma ending a word lowers to the final form
+		if r == 'Σ' && afterLetter && (kind == caseLower || (kind == caseTitle && inWord)) {
+			if next, _ := utf8.DecodeRune(s); len(s) == 0 || !unicode.IsLetter(next) {
+				dst = append(dst, "ς"...)
+				continue
+			}
+		}
+		afterLetter = unicode.IsLetter(r)
+
+		k := kind
+		if kind == caseTitle {
+			if inWord {
+				k = caseLower
+			}
+			inWord = isWordRune(r)
+		}
+		if special, ok := specialCase[r]; ok {
+			dst = append(dst, special[k]...)
+			continue
+		}
+		switch k {
+		case caseUpper:
+			r = unicode.ToUpper(r)
+		case caseLower:
+			r = unicode.ToLower(r)
+		case caseTitle:
+			r = unicode.ToTitle(r)
+		case caseFold:
+			r = unicode.ToLower(unicode.ToUpper(r))
+		}
+		dst = utf8.AppendRune(dst, r)
+	}
+	return dst
+}
+
+// changeCaseASCIIBytes converts the case of ASCII bytes in place
+func changeCaseASCIIBytes(b []byte, kind caseKind) {
+	switch kind {
+	case caseUpper:
+		for i, c := range b {
+			if isLowerASCII(c) {
+				b[i] = c - diffLowerUpper
+			}
+		}
+	case caseLower, caseFold:
+		for i, c := range b {
+			if isUpperASCII(c) {
+				b[i] = c + diffLowerUpper
+			}
+		}
+	case caseTitle:
+		toTitleWordsASCIIOneString(b)
+	}
+}
+
+func toTitleWordsASCII(x *vector.StringVector, start, stop int) {
+	for i := start; i < stop; i++ {
+		toTitleWordsASCIIOneString(x.Data()[x.Offsets()[i]:x.Offsets()[i+1]])
+	}
+}
+
+func toTitleWordsASCIIOneString(x []byte) {
+	inWord := false
+	for i, c := range x {
+		switch {
+		case !inWord && isLowerASCII(c):
+			x[i] = c - diffLowerUpper
+		case inWord && isUpperASCII(c):
+			x[i] = c + diffLowerUpper
+		}
+		inWord = isWordRune(rune(c))
+	}
+}
+
+// isWordRune returns whether r continues a word, for title-casing
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsMark(r) || r == '\'' || r == '’'
+}
+
+// isASCII returns whether all bytes of b are ASCII, checking 8 bytes at a time
+func isASCII(b []byte) bool {
+	for ; len(b) >= 8; b = b[8:] {
+		if binary.LittleEndian.Uint64(b)&0x8080808080808080 != 0 {
+			return false
+		}
+	}
+	for _, c := range b {
+		if c >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
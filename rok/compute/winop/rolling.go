@@ -0,0 +1,340 @@
+package winop
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// RollingOptions configures the windows of a rolling aggregation
+type RollingOptions struct {
+	Window     int  // number of rows in each window, or length of time-based windows
+	MinPeriods int  // minimum number of non-null elements for a non-null result; defaults to Window, or 1 for time-based windows
+	Center     bool // center each window on its row, rather than ending it at its row; fixed-count windows only, an error with Times
+
+	// Times, if set, makes windows time-based: the window of row i holds the rows with times in
+	// (Times[i] - Window, Times[i]]. Times must be sorted in ascending order.
+	Times []int64
+}
+
+// RollingSum returns the sum of the non-null elements in the window of each element of x.
+//
+// Integer sums wrap on overflow, as with Go's + operator.
+func RollingSum[T vector.Numeric](x *vector.NumericVector[T], opts RollingOptions) (*vector.NumericVector[T], error) {
+	return roll(x, opts, x.Type(), func() rollState[T] { return &sumState[T]{data: x.Data()} })
+}
+
+// RollingMean returns the mean of the non-null elements in the window of each element of x, as a float64
+func RollingMean[T vector.Numeric](x *vector.NumericVector[T], opts RollingOptions) (*vector.NumericVector[float64], error) {
+	return roll(x, opts, dtype.Float64{}, func() rollState[float64] { return &meanState[T]{sumState[T]{data: x.Data()}} })
+}
+
+// RollingStd returns the standard deviation of the non-null elements in the window of each element of x,
+// with n - ddof degrees of freedom, as a float64; windows with no more than ddof non-null elements are null
+func RollingStd[T vector.Numeric](x *vector.NumericVector[T], opts RollingOptions, ddof int) (*vector.NumericVector[float64], error) {
+	return roll(x, opts, dtype.Float64{}, func() rollState[float64] { return &stdState[T]{data: x.Data(), ddof: ddof} })
+}
+
+// RollingMin returns the minimum of the non-null elements in the window of each element of x; NaN values
+// are ignored, unless a window holds only NaN values
+func RollingMin[T vector.Numeric](x *vector.NumericVector[T], opts RollingOptions) (*vector.NumericVector[T], error) {
+	return roll(x, opts, x.Type(), func() rollState[T] {
+		return &extremeState[T]{data: x.Data(), better: func(a, b T) bool { return a < b }}
+	})
+}
+
+// RollingMax returns the maximum of the non-null elements in the window of each element of x; NaN values
+// are ignored, unless a window holds only NaN values
+func RollingMax[T vector.Numeric](x *vector.NumericVector[T], opts RollingOptions) (*vector.NumericVector[T], error) {
+	return roll(x, opts, x.Type(), func() rollState[T] {
+		return &extremeState[T]{data: x.Data(), better: func(a, b T) bool { return a > b }}
+	})
+}
+
+// rollState is the streaming state of a rolling aggregation, as non-null rows enter and leave the window
+type rollState[R vector.Numeric] interface {
+	add(i int)
+	remove(i int) // i is always the oldest row in the window
+	result(count int) (R, bool)
+}
+
+// roll computes a rolling aggregation, sliding a window over x and updating a rollState as rows enter and
+// leave it. Each worker slides its own window over its chunk of rows.
+func roll[T, R vector.Numeric](x *vector.NumericVector[T], opts RollingOptions, dType dtype.DataType, newState func() rollState[R]) (*vector.NumericVector[R], error) {
+	bounds, err := windowBounds(x.Len(), opts)
+	if err != nil {
+		return nil, err
+	}
+	minPeriods := opts.MinPeriods
+	if minPeriods <= 0 {
+		minPeriods = opts.Window
+		if opts.Times != nil {
+			minPeriods = 1
+		}
+	}
+
+	dataBuff := make([]R, x.Len())
+	validBuff := make([]byte, (x.Len()+7)/8)
+
+	// chunks are divisible by 8; each worker owns whole bytes of the validity bitmap
+	compute.ParallelChunks(x.Len(), 8, func(_, start, end int) {
+		if start == end {
+			return
+		}
+		st := newState()
+		lo, hi := bounds(start)
+		hi, count := lo, 0
+		for i := start; i < end; i++ {
+			l, h := bounds(i)
+			for ; hi < h; hi++ {
+				if !x.IsNull(hi) {
+					st.add(hi)
+					count++
+				}
+			}
+			for ; lo < l; lo++ {
+				if !x.IsNull(lo) {
+					st.remove(lo)
+					count--
+				}
+			}
+			if count == 0 || count < minPeriods {
+				continue
+			}
+			if val, ok := st.result(count); ok {
+				dataBuff[i] = val
+				validBuff[i/8] |= 1 << (i % 8)
+			}
+		}
+	})
+
+	validMap := vector.ValidityBitMap{
+		TrueLen:   x.Len(),
+		NullCount: vector.NullCountFromByteBuff(validBuff, x.Len()),
+		Buffer:    validBuff,
+	}
+	return vector.NumericVecFromComponents(dType, dataBuff, validMap), nil
+}
+
+// windowBounds returns a function giving the [lo, hi) rows of each row's window; both bounds never decrease
+func windowBounds(n int, opts RollingOptions) (func(i int) (int, int), error) {
+	if opts.Window <= 0 {
+		return nil, fmt.Errorf("rolling window must be positive, got %d", opts.Window)
+	}
+
+	if opts.Times == nil {
+		w := opts.Window
+		offset := w - 1
+		if opts.Center {
+			offset = w / 2
+		}
+		return func(i int) (int, int) {
+			lo := i - offset
+			return max(lo, 0), min(lo+w, n)
+		}, nil
+	}
+
+	if opts.Center {
+		return nil, fmt.Errorf("rolling windows cannot be centered when time-based")
+	}
+	times := opts.Times
+	if len(times) != n {
+		return nil, fmt.Errorf("mismatched lengths of rolling times %d and values %d", len(times), n)
+	}
+	// precompute bounds with two pointers
+	los, his := make([]int, n), make([]int, n)
+	lo, hi := 0, 0
+	for i, t := range times {
+		if i > 0 && t < times[i-1] {
+			return nil, fmt.Errorf("rolling times must be sorted in ascending order")
+		}
+		for lo < n && times[lo] <= t-int64(opts.Window) {
+			lo++
+		}
+		for hi < n && times[hi] <= t {
+			hi++
+		}
+		los[i], his[i] = lo, hi
+	}
+	return func(i int) (int, int) { return los[i], his[i] }, nil
+}
+
+// sumState is a running sum; float sums are compensated, and track non-finite values separately, so
+// that they can leave the window
+type sumState[T vector.Numeric] struct {
+	data []T
+	sum  T
+	fSum compute.Kahan
+	nan  int // NaN values in the window
+	pInf int // +Inf values in the window
+	nInf int // -Inf values in the window
+}
+
+func (s *sumState[T]) add(i int) {
+	s.update(s.data[i], 1)
+}
+
+func (s *sumState[T]) remove(i int) {
+	s.update(s.data[i], -1)
+}
+
+func (s *sumState[T]) update(x T, sign int) {
+	if !compute.IsFloat[T]() {
+		if sign > 0 {
+			s.sum += x
+		} else {
+			s.sum -= x
+		}
+		return
+	}
+	f := float64(x)
+	switch {
+	case f != f:
+		s.nan += sign
+	case math.IsInf(f, 1):
+		s.pInf += sign
+	case math.IsInf(f, -1):
+		s.nInf += sign
+	default:
+		s.fSum.Add(float64(sign) * f)
+	}
+}
+
+// floatResult returns the float64 sum of the window
+func (s *sumState[T]) floatResult() float64 {
+	switch {
+	case !compute.IsFloat[T]():
+		return float64(s.sum)
+	case s.nan > 0 || (s.pInf > 0 && s.nInf > 0):
+		return math.NaN()
+	case s.pInf > 0:
+		return math.Inf(1)
+	case s.nInf > 0:
+		return math.Inf(-1)
+	}
+	return s.fSum.Result()
+}
+
+func (s *sumState[T]) result(int) (T, bool) {
+	if !compute.IsFloat[T]() {
+		return s.sum, true
+	}
+	return T(s.floatResult()), true
+}
+
+type meanState[T vector.Numeric] struct {
+	sumState[T]
+}
+
+func (s *meanState[T]) result(count int) (float64, bool) {
+	return s.floatResult() / float64(count), true
+}
+
+// stdState tracks the window's mean and sum of squared deviations, with Welford's algorithm and its inverse
+type stdState[T vector.Numeric] struct {
+	data []T
+	ddof int
+	n    float64
+	mean float64
+	m2   float64
+	nan  int // non-finite values in the window
+	last float64
+	run  int // number of consecutive finite values equal to last, ending at the newest row
+}
+
+func (s *stdState[T]) add(i int) {
+	v := float64(s.data[i])
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		s.nan++
+		return
+	}
+	if s.run > 0 && v == s.last {
+		s.run++
+	} else {
+		s.last, s.run = v, 1
+	}
+	s.n++
+	delta := v - s.mean
+	s.mean += delta / s.n
+	s.m2 += delta * (v - s.mean)
+}
+
+func (s *stdState[T]) remove(i int) {
+	v := float64(s.data[i])
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		s.nan--
+		return
+	}
+	s.n--
+	if s.n == 0 {
+		s.mean, s.m2 = 0, 0
+		return
+	}
+	delta := v - s.mean
+	s.mean -= delta / s.n
+	s.m2 -= delta * (v - s.mean)
+}
+
+func (s *stdState[T]) result(count int) (float64, bool) {
+	if count <= s.ddof {
+		return 0, false
+	}
+	if s.nan > 0 {
+		return math.NaN(), true
+	}
+	// removing rows accumulates rounding error; a window of equal values has no deviation
+	if float64(s.run) >= s.n {
+		return 0, true
+	}
+	// rounding may leave a tiny negative sum of squares
+	return math.Sqrt(max(s.m2, 0) / (float64(count) - float64(s.ddof))), true
+}
+
+// extremeState keeps a monotonic deque of the window's rows: each row's value is better than those of all
+// rows after it, so the front of the deque is the window's extreme
+type extremeState[T vector.Numeric] struct {
+	data   []T
+	better func(a, b T) bool
+	deque  []int
+	head   int // index of the deque's front in deque
+	nan    int // NaN values in the window
+}
+
+func (s *extremeState[T]) add(i int) {
+	v := s.data[i]
+	// only NaN is not equal to itself
+	if v != v {
+		s.nan++
+		return
+	}
+	for len(s.deque) > s.head && !s.better(s.data[s.deque[len(s.deque)-1]], v) {
+		s.deque = s.deque[:len(s.deque)-1]
+	}
+	s.deque = append(s.deque, i)
+}
+
+func (s *extremeState[T]) remove(i int) {
+	if v := s.data[i]; v != v {
+		s.nan--
+		return
+	}
+	if s.head < len(s.deque) && s.deque[s.head] == i {
+		s.head++
+	}
+	// reclaim the consumed front of the deque
+	if s.head > 1024 && 2*s.head > len(s.deque) {
+		s.deque = append(s.deque[:0], s.deque[s.head:]...)
+		s.head = 0
+	}
+}
+
+func (s *extremeState[T]) result(int) (T, bool) {
+	if s.head == len(s.deque) {
+		// only NaN values
+		return T(math.NaN()), s.nan > 0
+	}
+	return s.data[s.deque[s.head]], true
+}
@@ -0,0 +1,151 @@
+package winop
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/hashop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Window kernels compute a value for each row from the other rows of its group (or partition), following
+// the order of each group's rows in a compute.Groups. Results are aligned with the original rows.
+
+// CumSum returns the cumulative sum of the non-null elements of x within each group; null elements stay null.
+//
+// Integer sums wrap on overflow, as with Go's + operator.
+func CumSum[T vector.Numeric](x *vector.NumericVector[T], g *compute.Groups) *vector.NumericVector[T] {
+	dataBuff := make([]T, x.Len())
+	data := x.Data()
+
+	// results are scattered to arbitrary rows; workers own groups, not bitmap bytes
+	compute.ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			var sum T
+			for _, r := range g.GroupRows(i) {
+				if !x.IsNull(r) {
+					sum += data[r]
+				}
+				dataBuff[r] = sum
+			}
+		}
+	})
+	return vector.NumericVecFromComponents(x.Type(), dataBuff, x.Validity().DeepCopy())
+}
+
+// RowNumber returns the 1-based position of each of the n rows within its group
+func RowNumber(g *compute.Groups, n int) *vector.NumericVector[int64] {
+	dataBuff := make([]int64, n)
+	compute.ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			for j, r := range g.GroupRows(i) {
+				dataBuff[r] = int64(j + 1)
+			}
+		}
+	})
+	return vector.NumericVecFromComponents(dtype.Int64{}, dataBuff, vector.NewValidityBitMap(n))
+}
+
+// Rank returns the rank of each element of v within its group, where the rows of each group are sorted
+// in ranking order; equal elements share the lowest rank of their run (1, 1, 3), or with dense, ranks
+// have no gaps (1, 1, 2). Null elements have a null rank, and are not counted.
+func Rank(v vector.Vector, g *compute.Groups, dense bool) (*vector.NumericVector[int64], error) {
+	dataBuff, err := rankRows([]vector.Vector{v}, g, dense, v.IsNull)
+	if err != nil {
+		return nil, err
+	}
+	return vector.NumericVecFromComponents(dtype.Int64{}, dataBuff, v.Validity().DeepCopy()), nil
+}
+
+// RankMulti returns the rank of each row of the key vectors within its group, where the rows of each group
+// are sorted in ranking order; rows equal on every key share a rank (see Rank). A null key is equal only
+// to another null, and every row has a rank.
+func RankMulti(keys []vector.Vector, g *compute.Groups, dense bool) (*vector.NumericVector[int64], error) {
+	if len(keys) == 0 {
+		return nil, fmt.Errorf("at least one rank key is required")
+	}
+	dataBuff, err := rankRows(keys, g, dense, func(int) bool { return false })
+	if err != nil {
+		return nil, err
+	}
+	return vector.NumericVecFromComponents(dtype.Int64{}, dataBuff, vector.NewValidityBitMap(len(dataBuff))), nil
+}
+
+// rankRows returns the rank of each row by the key vectors, not counting the rows for which skip is true
+func rankRows(keys []vector.Vector, g *compute.Groups, dense bool, skip func(r int) bool) ([]int64, error) {
+	eq, err := hashop.RowsEqual(keys, keys)
+	if err != nil {
+		return nil, err
+	}
+	dataBuff := make([]int64, keys[0].Len())
+
+	compute.ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			var rank, pos int64
+			prev := -1
+			for _, r := range g.GroupRows(i) {
+				if skip(r) {
+					continue
+				}
+				pos++
+				if prev == -1 || !eq(prev, r) {
+					if dense {
+						rank++
+					} else {
+						rank = pos
+					}
+				}
+				dataBuff[r] = rank
+				prev = r
+			}
+		}
+	})
+	return dataBuff, nil
+}
+
+// ShiftIndices returns, for each of the n rows, the index of the row `offset` positions later within its
+// group (earlier, for a negative offset), or -1 if there is none; for use with selop.Take
+func ShiftIndices(g *compute.Groups, n, offset int) []int {
+	indices := make([]int, n)
+	compute.ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			rows := g.GroupRows(i)
+			for j, r := range rows {
+				if k := j + offset; k >= 0 && k < len(rows) {
+					indices[r] = rows[k]
+				} else {
+					indices[r] = -1
+				}
+			}
+		}
+	})
+	return indices
+}
+
+// FirstIndices returns, for each of the n rows, the index of the first row of its group; for use with selop.Take
+func FirstIndices(g *compute.Groups, n int) []int {
+	return broadcastIndices(g, n, func(rows []int) int { return rows[0] })
+}
+
+// LastIndices returns, for each of the n rows, the index of the last row of its group; for use with selop.Take
+func LastIndices(g *compute.Groups, n int) []int {
+	return broadcastIndices(g, n, func(rows []int) int { return rows[len(rows)-1] })
+}
+
+func broadcastIndices(g *compute.Groups, n int, pick func(rows []int) int) []int {
+	indices := make([]int, n)
+	compute.ParallelChunks(g.Len(), 1, func(_, start, end int) {
+		for i := start; i < end; i++ {
+			rows := g.GroupRows(i)
+			if len(rows) == 0 {
+				continue
+			}
+			src := pick(rows)
+			for _, r := range rows {
+				indices[r] = src
+			}
+		}
+	})
+	return indices
+}
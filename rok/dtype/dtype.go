@@ -38,3 +38,28 @@ const (
 
 	DATE
 )
+
+// IsNumeric returns whether a LogicalType is an integer or floating point type
+func IsNumeric(t LogicalType) bool {
+	return IsInteger(t) || IsFloat(t)
+}
+
+// IsInteger returns whether a LogicalType is a signed or unsigned integer type
+func IsInteger(t LogicalType) bool {
+	return IsSignedInteger(t) || IsUnsignedInteger(t)
+}
+
+// IsSignedInteger returns whether a LogicalType is a signed integer type
+func IsSignedInteger(t LogicalType) bool {
+	return t == INT8 || t == INT16 || t == INT32 || t == INT64
+}
+
+// IsUnsignedInteger returns whether a LogicalType is an unsigned integer type
+func IsUnsignedInteger(t LogicalType) bool {
+	return t == UINT8 || t == UINT16 || t == UINT32 || t == UINT64
+}
+
+// IsFloat returns whether a LogicalType is a floating point type
+func IsFloat(t LogicalType) bool {
+	return t == FLOAT32 || t == FLOAT64
+}
@@ -0,0 +1,62 @@
+package dtype
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Field represents a named column of a DataType
+type Field struct {
+	Name  string
+	DType DataType
+}
+
+// Schema represents the ordered columns of a table
+type Schema []Field
+
+// Names returns the column names of the Schema, in order
+func (s Schema) Names() []string {
+	names := make([]string, len(s))
+	for i, f := range s {
+		names[i] = f.Name
+	}
+	return names
+}
+
+// Lookup returns the DataType of the column `name`, and whether the Schema holds it
+func (s Schema) Lookup(name string) (DataType, bool) {
+	for _, f := range s {
+		if f.Name == name {
+			return f.DType, true
+		}
+	}
+	return nil, false
+}
+
+// String returns the Schema as a list of names and types, one column per line, with types aligned:
+//
+//	Schema (2 columns)
+//	  id    int64
+//	  name  string
+func (s Schema) String() string {
+	width := 0
+	for _, f := range s {
+		width = max(width, utf8.RuneCountInString(f.Name))
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Schema (%d %s)", len(s), plural(len(s), "column"))
+	for _, f := range s {
+		pad := width - utf8.RuneCountInString(f.Name) + 2
+		fmt.Fprintf(&b, "\n  %s%s%v", f.Name, strings.Repeat(" ", pad), f.DType)
+	}
+	return b.String()
+}
+
+func plural(n int, word string) string {
+	if n == 1 {
+		return word
+	}
+	return word + "s"
+}
@@ -0,0 +1,21 @@
+package frame
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Cast returns an expression converting an expression to the DataType `to` (see compute.Cast)
+func (c ColExpr) Cast(to dtype.DataType, opts compute.CastOptions) ColExpr {
+	return c.call(&exprFunc{
+		name:   "cast",
+		params: []any{to},
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			return to, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			return compute.Cast(in[0], to, opts)
+		},
+	})
+}
@@ -0,0 +1,77 @@
+package frame
+
+import (
+	"github.com/rhawrami/rok-frame/rok/compute/boolop"
+	"github.com/rhawrami/rok-frame/rok/compute/dateop"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/compute/strop"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+func isComparison(op exprOp) bool {
+	return op == opEq || op == opNe || op == opGt || op == opLt || op == opGe || op == opLe
+}
+
+// numeric kernels, by operator; operands of differing types are promoted
+
+var numericOps = map[exprOp]func(x, y vector.Vector) (vector.Vector, error){
+	opAdd: numop.Add, opSub: numop.Sub, opMul: numop.Mul, opDiv: numop.Div, opMod: numop.Mod, opFloorDiv: numop.FloorDiv,
+}
+
+var numericCmps = map[exprOp]func(x, y vector.Vector) (vector.Vector, error){
+	opEq: boolResult(numop.Eq), opNe: boolResult(numop.Ne), opGt: boolResult(numop.Gt),
+	opLt: boolResult(numop.Lt), opGe: boolResult(numop.Ge), opLe: boolResult(numop.Le),
+}
+
+// boolResult adapts a comparison kernel to return a Vector
+func boolResult(fn func(x, y vector.Vector) (*vector.BoolVector, error)) func(x, y vector.Vector) (vector.Vector, error) {
+	return func(x, y vector.Vector) (vector.Vector, error) {
+		res, err := fn(x, y)
+		if err != nil {
+			return nil, err
+		}
+		return res, nil
+	}
+}
+
+// comparison kernels, by operator
+
+var strCmpVec = map[exprOp]func(x, y *vector.StringVector) *vector.BoolVector{
+	opEq: strop.EqVec, opNe: strop.NeVec, opGt: strop.GtVec, opLt: strop.LtVec, opGe: strop.GeVec, opLe: strop.LeVec,
+}
+
+var strCmpLit = map[exprOp]func(x *vector.StringVector, lit []byte) *vector.BoolVector{
+	opEq: strop.EqLit, opNe: strop.NeLit, opGt: strop.GtLit, opLt: strop.LtLit, opGe: strop.GeLit, opLe: strop.LeLit,
+}
+
+var dateCmpVec = map[exprOp]func(x, y *vector.DateVector) *vector.BoolVector{
+	opEq: dateop.EqVec, opNe: dateop.NeVec, opGt: dateop.GtVec, opLt: dateop.LtVec, opGe: dateop.GeVec, opLe: dateop.LeVec,
+}
+
+var dateCmpLit = map[exprOp]func(x *vector.DateVector, lit int32) *vector.BoolVector{
+	opEq: dateop.EqLit, opNe: dateop.NeLit, opGt: dateop.GtLit, opLt: dateop.LtLit, opGe: dateop.GeLit, opLe: dateop.LeLit,
+}
+
+var boolCmpVec = map[exprOp]func(x, y *vector.BoolVector) *vector.BoolVector{
+	opEq: boolop.EqVec, opNe: boolop.NeVec, opGt: boolop.GtVec, opLt: boolop.LtVec, opGe: boolop.GeVec, opLe: boolop.LeVec,
+}
+
+var boolCmpLit = map[exprOp]func(x *vector.BoolVector, lit bool) *vector.BoolVector{
+	opEq: boolop.EqLit, opNe: boolop.NeLit, opGt: boolop.GtLit, opLt: boolop.LtLit, opGe: boolop.GeLit, opLe: boolop.LeLit,
+}
+
+func numericCmpLit[T vector.Numeric](op exprOp, x *vector.NumericVector[T], lit T) *vector.BoolVector {
+	switch op {
+	case opEq:
+		return numop.EqLit(x, lit)
+	case opNe:
+		return numop.NeLit(x, lit)
+	case opGt:
+		return numop.GtLit(x, lit)
+	case opLt:
+		return numop.LtLit(x, lit)
+	case opGe:
+		return numop.GeLit(x, lit)
+	}
+	return numop.LeLit(x, lit)
+}
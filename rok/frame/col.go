@@ -1,39 +1,341 @@
 package frame
 
+import (
+	"fmt"
+	"strings"
+)
+
+// exprKind represents the kind of node in an expression tree
+type exprKind int
+
+const (
+	colExpr    exprKind = iota // reference to a frame column
+	litExpr                    // scalar literal value
+	binaryExpr                 // binary operation on two sub-expressions
+	unaryExpr                  // unary operation on one sub-expression
+	funcExpr                   // function call on zero or more sub-expressions
+	aggExpr                    // aggregation of one sub-expression, such as in GroupBy.Agg
+	windowExpr                 // window function, computed over partitions of rows
+)
+
+// exprOp represents a binary or unary operator
+type exprOp int
+
+const (
+	opAdd exprOp = iota
+	opSub
+	opMul
+	opDiv
+	opMod
+	opFloorDiv
+
+	opEq
+	opNe
+	opGt
+	opLt
+	opGe
+	opLe
+
+	opAnd
+	opOr
+	opXor
+	opAndNot
+
+	opNot
+	opNeg
+)
+
+// aggKind represents an aggregation
+type aggKind int
+
+const (
+	aggSum aggKind = iota
+	aggProduct
+	aggMean
+	aggMin
+	aggMax
+	aggCount
+	aggCountNull
+	aggVar
+	aggStd
+)
+
+var aggKindNames = map[aggKind]string{
+	aggSum:       "sum",
+	aggProduct:   "product",
+	aggMean:      "mean",
+	aggMin:       "min",
+	aggMax:       "max",
+	aggCount:     "count",
+	aggCountNull: "count_null",
+	aggVar:       "var",
+	aggStd:       "std",
+}
+
+var exprOpSymbols = map[exprOp]string{
+	opAdd:      "+",
+	opSub:      "-",
+	opMul:      "*",
+	opDiv:      "/",
+	opMod:      "%",
+	opFloorDiv: "//",
+	opEq:       "==",
+	opNe:       "!=",
+	opGt:       ">",
+	opLt:       "<",
+	opGe:       ">=",
+	opLe:       "<=",
+	opAnd:      "&",
+	opOr:       "|",
+	opXor:      "^",
+	opAndNot:   "&~",
+	opNot:      "~",
+	opNeg:      "-",
+}
+
+// ColExpr represents an expression tree, built up from column references,
+// literals, binary and unary operations, function calls, aggregations and window functions.
+//
+// A ColExpr is evaluated against a Frame with Frame.Eval; Name is the name given to the
+// resulting column, which defaults to the name of the left-most column referenced.
 type ColExpr struct {
 	Name string
+
+	kind exprKind
+	op   exprOp
+	agg  aggKind
+	lit  any
+	fn   *exprFunc
+	win  *window
+	args []ColExpr
 }
 
-func Col(name string) ColExpr { return ColExpr{Name: name} }
+// Col returns an expression referencing the column `name`
+func Col(name string) ColExpr { return ColExpr{Name: name, kind: colExpr} }
 
+// Lit returns an expression representing a scalar literal value.
+//
+// Supported literal types are Go numeric types, string, []byte, bool and time.Time (as a date).
+func Lit(x any) ColExpr {
+	if e, ok := x.(ColExpr); ok {
+		return e
+	}
+	return ColExpr{Name: "literal", kind: litExpr, lit: x}
+}
+
+// Eq returns an expression evaluating whether c is equal to x
 func (c ColExpr) Eq(x any) ColExpr {
-	return c
+	return c.binary(opEq, x)
+}
+
+// Ne returns an expression evaluating whether c is not equal to x
+func (c ColExpr) Ne(x any) ColExpr {
+	return c.binary(opNe, x)
 }
 
+// Gt returns an expression evaluating whether c is greater than x
 func (c ColExpr) Gt(x any) ColExpr {
-	return c
+	return c.binary(opGt, x)
 }
 
+// Lt returns an expression evaluating whether c is less than x
 func (c ColExpr) Lt(x any) ColExpr {
-	return c
+	return c.binary(opLt, x)
 }
 
+// Ge returns an expression evaluating whether c is greater than or equal to x
 func (c ColExpr) Ge(x any) ColExpr {
-	return c
+	return c.binary(opGe, x)
 }
 
+// Le returns an expression evaluating whether c is less than or equal to x
 func (c ColExpr) Le(x any) ColExpr {
-	return c
+	return c.binary(opLe, x)
 }
 
+// And returns the logical conjunction of c and x; both must be boolean.
+//
+// Nulls follow SQL (Kleene) semantics, e.g. false AND null is false.
 func (c ColExpr) And(x any) ColExpr {
-	return c
+	return c.binary(opAnd, x)
 }
 
+// Or returns the logical disjunction of c and x; both must be boolean.
+//
+// Nulls follow SQL (Kleene) semantics, e.g. true OR null is true.
 func (c ColExpr) Or(x any) ColExpr {
-	return c
+	return c.binary(opOr, x)
+}
+
+// Xor returns the logical exclusive disjunction of c and x; both must be boolean
+func (c ColExpr) Xor(x any) ColExpr {
+	return c.binary(opXor, x)
+}
+
+// AndNot returns the logical conjunction of c and the negation of x; both must be boolean
+func (c ColExpr) AndNot(x any) ColExpr {
+	return c.binary(opAndNot, x)
+}
+
+// Not returns the logical negation of c; c must be boolean
+func (c ColExpr) Not() ColExpr {
+	return c.unary(opNot)
+}
+
+// Add returns an expression evaluating the sum of c and x
+func (c ColExpr) Add(x any) ColExpr {
+	return c.binary(opAdd, x)
+}
+
+// Sub returns an expression evaluating the difference of c and x
+func (c ColExpr) Sub(x any) ColExpr {
+	return c.binary(opSub, x)
+}
+
+// Mul returns an expression evaluating the product of c and x
+func (c ColExpr) Mul(x any) ColExpr {
+	return c.binary(opMul, x)
 }
 
-func (c ColExpr) Not(x any) ColExpr {
+// Div returns an expression evaluating the quotient of c and x; integer division by zero evaluates to null
+func (c ColExpr) Div(x any) ColExpr {
+	return c.binary(opDiv, x)
+}
+
+// Mod returns an expression evaluating the remainder of c divided by x, taking the sign of c;
+// integer division by zero evaluates to null
+func (c ColExpr) Mod(x any) ColExpr {
+	return c.binary(opMod, x)
+}
+
+// FloorDiv returns an expression evaluating the quotient of c and x, rounded towards negative infinity;
+// integer division by zero evaluates to null
+func (c ColExpr) FloorDiv(x any) ColExpr {
+	return c.binary(opFloorDiv, x)
+}
+
+// Neg returns an expression evaluating the negation of c
+func (c ColExpr) Neg() ColExpr {
+	return c.unary(opNeg)
+}
+
+// Alias returns the expression, naming its resulting column `name`
+func (c ColExpr) Alias(name string) ColExpr {
+	if c.kind == colExpr {
+		c.lit = c.column()
+	}
+	c.Name = name
 	return c
 }
+
+// column returns the name of the column referenced by a colExpr, which Alias leaves unchanged
+func (c ColExpr) column() string {
+	if ref, ok := c.lit.(string); ok {
+		return ref
+	}
+	return c.Name
+}
+
+// Sum returns an aggregation of the sum of c's non-null elements; c must be numeric, and an integer sum
+// that overflows c's type is null
+func (c ColExpr) Sum() ColExpr {
+	return c.aggregate(aggSum, nil)
+}
+
+// Product returns an aggregation of the product of c's non-null elements; c must be numeric
+func (c ColExpr) Product() ColExpr {
+	return c.aggregate(aggProduct, nil)
+}
+
+// Mean returns an aggregation of the mean of c's non-null elements, as a float64; c must be numeric
+func (c ColExpr) Mean() ColExpr {
+	return c.aggregate(aggMean, nil)
+}
+
+// Min returns an aggregation of the minimum of c's non-null elements; c must be numeric, string or date
+func (c ColExpr) Min() ColExpr {
+	return c.aggregate(aggMin, nil)
+}
+
+// Max returns an aggregation of the maximum of c's non-null elements; c must be numeric, string or date
+func (c ColExpr) Max() ColExpr {
+	return c.aggregate(aggMax, nil)
+}
+
+// Count returns an aggregation of the number of c's non-null elements, as an int64
+func (c ColExpr) Count() ColExpr {
+	return c.aggregate(aggCount, nil)
+}
+
+// CountNull returns an aggregation of the number of c's null elements, as an int64
+func (c ColExpr) CountNull() ColExpr {
+	return c.aggregate(aggCountNull, nil)
+}
+
+// Var returns an aggregation of the variance of c's non-null elements, with n - ddof degrees of freedom,
+// as a float64; c must be numeric
+func (c ColExpr) Var(ddof int) ColExpr {
+	return c.aggregate(aggVar, ddof)
+}
+
+// Std returns an aggregation of the standard deviation of c's non-null elements, with n - ddof degrees
+// of freedom, as a float64; c must be numeric
+func (c ColExpr) Std(ddof int) ColExpr {
+	return c.aggregate(aggStd, ddof)
+}
+
+func (c ColExpr) aggregate(agg aggKind, param any) ColExpr {
+	return ColExpr{Name: c.Name, kind: aggExpr, agg: agg, lit: param, args: []ColExpr{c}}
+}
+
+func (c ColExpr) binary(op exprOp, x any) ColExpr {
+	return ColExpr{Name: c.Name, kind: binaryExpr, op: op, args: []ColExpr{c, Lit(x)}}
+}
+
+func (c ColExpr) unary(op exprOp) ColExpr {
+	return ColExpr{Name: c.Name, kind: unaryExpr, op: op, args: []ColExpr{c}}
+}
+
+// call returns a function call expression, applying fn to c and any additional arguments
+func (c ColExpr) call(fn *exprFunc, x ...any) ColExpr {
+	args := make([]ColExpr, 0, len(x)+1)
+	args = append(args, c)
+	for _, v := range x {
+		args = append(args, Lit(v))
+	}
+	return ColExpr{Name: c.Name, kind: funcExpr, fn: fn, args: args}
+}
+
+// String returns a readable representation of the expression tree
+func (c ColExpr) String() string {
+	switch c.kind {
+	case colExpr:
+		return fmt.Sprintf("col(%s)", c.column())
+	case litExpr:
+		if s, ok := c.lit.(string); ok {
+			return fmt.Sprintf("%q", s)
+		}
+		return fmt.Sprintf("%v", c.lit)
+	case binaryExpr:
+		return fmt.Sprintf("(%s %s %s)", c.args[0], exprOpSymbols[c.op], c.args[1])
+	case unaryExpr:
+		return fmt.Sprintf("%s%s", exprOpSymbols[c.op], c.args[0])
+	case funcExpr:
+		argStrs := make([]string, 0, len(c.args)+len(c.fn.params))
+		for _, a := range c.args {
+			argStrs = append(argStrs, a.String())
+		}
+		for _, p := range c.fn.params {
+			argStrs = append(argStrs, Lit(p).String())
+		}
+		return fmt.Sprintf("%s(%s)", c.fn.name, strings.Join(argStrs, ", "))
+	case aggExpr:
+		if c.lit != nil {
+			return fmt.Sprintf("%s(%s, %v)", aggKindNames[c.agg], c.args[0], c.lit)
+		}
+		return fmt.Sprintf("%s(%s)", aggKindNames[c.agg], c.args[0])
+	case windowExpr:
+		return c.win.String(c.args)
+	}
+	return "<invalid>"
+}
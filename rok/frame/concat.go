@@ -0,0 +1,135 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// ConcatType determines how Concat aligns the columns of frames
+type ConcatType int
+
+const (
+	// VerticalConcat stacks frames holding the same columns, matched by name, of the same types
+	VerticalConcat ConcatType = iota
+	// DiagonalConcat stacks the union of all columns; columns missing from a frame are filled with nulls, and
+	// numeric columns of differing types are promoted to a common type
+	DiagonalConcat
+)
+
+// Concat returns a new Frame, stacking the rows of each frame in order.
+//
+// Columns are matched by name, and ordered as in the first frame; with DiagonalConcat, columns missing from
+// the first frame follow, in order of appearance. With VerticalConcat, columns of the same name must be of
+// the same type; DiagonalConcat promotes numeric columns of differing types to a common type.
+func Concat(frames []*Frame, how ConcatType) (*Frame, error) {
+	if len(frames) == 0 {
+		return nil, fmt.Errorf("Concat requires at least one Frame")
+	}
+
+	if how == VerticalConcat {
+		for i, fr := range frames[1:] {
+			if !sameColumns(fr, frames[0]) {
+				return nil, fmt.Errorf("Frame %d of Concat has columns %v, expected %v; use DiagonalConcat to fill missing columns with nulls",
+					i+1, fr.Schema().Names(), frames[0].Schema().Names())
+			}
+		}
+	}
+
+	// column names and types, in order
+	names := make([]string, 0, len(frames[0].Cols))
+	types := make(map[string]dtype.DataType)
+	for _, fr := range frames {
+		for _, col := range fr.Cols {
+			t, ok := types[col.Name]
+			if !ok {
+				names = append(names, col.Name)
+				types[col.Name] = col.DType
+				continue
+			}
+			if how == DiagonalConcat && dtype.IsNumeric(t.Type()) && dtype.IsNumeric(col.DType.Type()) {
+				var err error
+				if types[col.Name], err = numop.PromoteTypes(t, col.DType); err != nil {
+					return nil, err
+				}
+			} else if t.Type() != col.DType.Type() {
+				return nil, fmt.Errorf("mismatched types %v and %v of column '%s' in Concat", t, col.DType, col.Name)
+			}
+		}
+	}
+
+	newCols := make([]*Column, len(names))
+	for i, name := range names {
+		vec, err := concatColumn(frames, name, types[name])
+		if err != nil {
+			return nil, err
+		}
+		newCols[i] = &Column{Name: name, DType: types[name], Vec: vec}
+	}
+	return fromCols(newCols)
+}
+
+// sameColumns returns whether two frames hold the same column names, in any order
+func sameColumns(f, other *Frame) bool {
+	if len(f.Cols) != len(other.Cols) {
+		return false
+	}
+	for _, col := range f.Cols {
+		if _, ok := other.NameColMap[col.Name]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// concatColumn stacks the column `name` of each frame, as type t; frames without the column give nulls
+func concatColumn(frames []*Frame, name string, t dtype.DataType) (vector.Vector, error) {
+	parts := make([]vector.Vector, len(frames))
+	var proto vector.Vector
+	for i, fr := range frames {
+		colIdx, ok := fr.NameColMap[name]
+		if !ok {
+			continue
+		}
+		vec := fr.Cols[colIdx].Vec
+		if dtype.IsNumeric(t.Type()) {
+			var err error
+			if vec, err = numop.Promote(vec, t); err != nil {
+				return nil, err
+			}
+		}
+		parts[i] = vec
+		if proto == nil {
+			proto = vec
+		}
+	}
+
+	for i, fr := range frames {
+		if parts[i] != nil {
+			continue
+		}
+		// a negative index gives a null element
+		indices := make([]int, fr.Height())
+		for j := range indices {
+			indices[j] = -1
+		}
+		nulls, err := selop.Take(proto, indices)
+		if err != nil {
+			return nil, err
+		}
+		parts[i] = nulls
+	}
+	return selop.Concat(parts...)
+}
+
+// HStack returns a new Frame of the columns of f, followed by those of other; the frames must be of equal
+// height, and their column names unique. The columns share the vectors of both frames.
+func (f *Frame) HStack(other *Frame) (*Frame, error) {
+	if len(f.Cols) > 0 && len(other.Cols) > 0 && f.Height() != other.Height() {
+		return nil, fmt.Errorf("HStack expects frames of equal height, got %d and %d", f.Height(), other.Height())
+	}
+	return fromCols(append(f.copyCols(), other.copyCols()...))
+}
@@ -0,0 +1,134 @@
+package frame
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/rhawrami/rok-frame/rok/compute/dateop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Year returns an expression evaluating the year of a date expression, as an int32
+func (c ColExpr) Year() ColExpr {
+	return c.call(dateFunc("year", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Year(x[0])
+	}))
+}
+
+// Month returns an expression evaluating the month (1 to 12) of a date expression, as an int32
+func (c ColExpr) Month() ColExpr {
+	return c.call(dateFunc("month", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Month(x[0])
+	}))
+}
+
+// Day returns an expression evaluating the day of the month of a date expression, as an int32
+func (c ColExpr) Day() ColExpr {
+	return c.call(dateFunc("day", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Day(x[0])
+	}))
+}
+
+// Weekday returns an expression evaluating the day of the week of a date expression, from Sunday (0) to
+// Saturday (6), as an int32
+func (c ColExpr) Weekday() ColExpr {
+	return c.call(dateFunc("weekday", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Weekday(x[0])
+	}))
+}
+
+// DayOfYear returns an expression evaluating the day of the year (1 to 366) of a date expression, as an int32
+func (c ColExpr) DayOfYear() ColExpr {
+	return c.call(dateFunc("day_of_year", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.DayOfYear(x[0])
+	}))
+}
+
+// Quarter returns an expression evaluating the quarter (1 to 4) of a date expression, as an int32
+func (c ColExpr) Quarter() ColExpr {
+	return c.call(dateFunc("quarter", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Quarter(x[0])
+	}))
+}
+
+// ISOWeek returns an expression evaluating the ISO 8601 week number (1 to 53) of a date expression, as an int32
+func (c ColExpr) ISOWeek() ColExpr {
+	return c.call(dateFunc("iso_week", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.ISOWeek(x[0])
+	}))
+}
+
+// AddDays returns an expression shifting a date expression by n days
+func (c ColExpr) AddDays(n int) ColExpr {
+	return c.call(dateFunc("add_days", []any{n}, dtype.Date{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.AddDays(x[0], int32(n))
+	}))
+}
+
+// AddMonths returns an expression shifting a date expression by n months, clamping days past the end of
+// the resulting month to its last day
+func (c ColExpr) AddMonths(n int) ColExpr {
+	return c.call(dateFunc("add_months", []any{n}, dtype.Date{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.AddMonths(x[0], int32(n))
+	}))
+}
+
+// DiffDays returns an expression evaluating the number of days from x to a date expression, as an int32;
+// x is a date expression, or a date literal (time.Time, or string in YYYY-MM-DD format)
+func (c ColExpr) DiffDays(x any) ColExpr {
+	if s, ok := x.(string); ok {
+		if d, err := dateLit(s); err == nil {
+			x = time.Unix(int64(d)*secsInOneDay, 0).UTC()
+		}
+	}
+	return c.call(dateFunc("diff_days", nil, dtype.Int32{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.DiffDays(x[0], x[1])
+	}), x)
+}
+
+// Truncate returns an expression truncating a date expression to the first day of its week, month,
+// quarter or year
+func (c ColExpr) Truncate(unit dateop.TruncUnit) ColExpr {
+	return c.call(dateFunc("truncate", []any{truncUnitNames[unit]}, dtype.Date{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Truncate(x[0], unit)
+	}))
+}
+
+// Format returns an expression formatting a date expression following layout, a Go time layout; time
+// elements are formatted as midnight UTC (see dateop.Format)
+func (c ColExpr) Format(layout string) ColExpr {
+	return c.call(dateFunc("format", []any{layout}, dtype.String{}, func(x []*vector.DateVector) vector.Vector {
+		return dateop.Format(x[0], layout)
+	}))
+}
+
+var truncUnitNames = map[dateop.TruncUnit]string{
+	dateop.TruncWeek:    "week",
+	dateop.TruncMonth:   "month",
+	dateop.TruncQuarter: "quarter",
+	dateop.TruncYear:    "year",
+}
+
+// dateFunc returns a function call node whose arguments are all dates, and whose output is of type out
+func dateFunc(name string, params []any, out dtype.DataType, fn func(x []*vector.DateVector) vector.Vector) *exprFunc {
+	return &exprFunc{
+		name:   name,
+		params: params,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			for _, t := range in {
+				if t.Type() != dtype.DATE {
+					return nil, fmt.Errorf("%s: arguments must be date, got %v", name, t)
+				}
+			}
+			return out, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			args := make([]*vector.DateVector, len(in))
+			for i, v := range in {
+				args[i] = v.(*vector.DateVector)
+			}
+			return fn(args), nil
+		},
+	}
+}
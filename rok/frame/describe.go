@@ -0,0 +1,175 @@
+package frame
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/rhawrami/rok-frame/rok/compute/dateop"
+	"github.com/rhawrami/rok-frame/rok/compute/hashop"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Schema returns the names and DataTypes of the Frame's columns, in order
+func (f *Frame) Schema() dtype.Schema {
+	s := make(dtype.Schema, len(f.Cols))
+	for i, col := range f.Cols {
+		s[i] = dtype.Field{Name: col.Name, DType: col.DType}
+	}
+	return s
+}
+
+// describeStats are the rows of Describe, in order
+var describeStats = []string{"count", "null_count", "mean", "std", "min", "25%", "50%", "75%", "max", "n_unique", "top", "freq"}
+
+const (
+	statCount = iota
+	statNullCount
+	statMean
+	statStd
+	statMin
+	stat25
+	stat50
+	stat75
+	statMax
+	statNUnique
+	statTop
+	statFreq
+)
+
+// Describe returns a new Frame of summary statistics, with a "statistic" column naming each row, followed
+// by one column per column of the Frame:
+//   - numeric columns give count, null_count, mean, std (sample), min, quartiles and max, as float64s
+//   - date columns give count, null_count, mean, min, quartiles and max, as strings; dates are formatted
+//     as YYYY-MM-DD, and rounded down to the day
+//   - string and bool columns give count, null_count, n_unique (of non-null elements), top (the most
+//     frequent non-null element) and freq (its count), as strings
+//
+// Statistics not applicable to a column are null, as are those of columns without non-null elements.
+// Float NaN elements are counted, and propagate to mean and std; min, quartiles and max ignore them.
+func (f *Frame) Describe() (*Frame, error) {
+	if _, ok := f.NameColMap["statistic"]; ok {
+		return nil, fmt.Errorf("duplicate column name 'statistic'; rename the column to describe it")
+	}
+	allValid := make([]bool, len(describeStats))
+	for i := range allValid {
+		allValid[i] = true
+	}
+	newCols := []*Column{{Name: "statistic", DType: dtype.String{}, Vec: vector.StringVecFromStrings(describeStats, allValid)}}
+	newNameColMap := map[string]int{"statistic": 0}
+
+	for _, col := range f.Cols {
+		var vec vector.Vector
+		var err error
+		switch t := col.DType.Type(); {
+		case dtype.IsNumeric(t):
+			vec, err = describeNumeric(col.Vec)
+		case t == dtype.DATE:
+			vec, err = describeDate(col.Vec.(*vector.DateVector))
+		default:
+			vec, err = describeValues(col.Vec)
+		}
+		if err != nil {
+			return nil, err
+		}
+		newNameColMap[col.Name] = len(newCols)
+		newCols = append(newCols, &Column{Name: col.Name, DType: vec.Type(), Vec: vec})
+	}
+	return &Frame{Cols: newCols, NameColMap: newNameColMap}, nil
+}
+
+// describeNumeric returns the float64 statistics of a numeric vector
+func describeNumeric(v vector.Vector) (vector.Vector, error) {
+	stats, valid, err := numericStats(v)
+	if err != nil {
+		return nil, err
+	}
+	return vector.NumericVecFromComponents(dtype.Float64{}, stats, vector.ValidityBitMapFromBools(valid)), nil
+}
+
+// describeDate returns the statistics of a date vector, as strings
+func describeDate(x *vector.DateVector) (vector.Vector, error) {
+	days := vector.NumericVecFromComponents(dtype.Int32{}, x.Data(), x.Validity())
+	stats, valid, err := numericStats(days)
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, len(describeStats))
+	for i, s := range stats {
+		switch {
+		case !valid[i]:
+		case i == statCount || i == statNullCount:
+			strs[i] = strconv.FormatFloat(s, 'f', -1, 64)
+		case i == statStd:
+			valid[i] = false
+		default:
+			strs[i] = dateop.FormatDate(int32(math.Floor(s)), dateLitLayout)
+		}
+	}
+	return vector.StringVecFromStrings(strs, valid), nil
+}
+
+// numericStats returns the statistics of a numeric vector, and whether each is valid
+func numericStats(v vector.Vector) ([]float64, []bool, error) {
+	fv, err := numop.Promote(v, dtype.Float64{})
+	if err != nil {
+		return nil, nil, err
+	}
+	x := fv.(*vector.NumericVector[float64])
+
+	stats := make([]float64, len(describeStats))
+	valid := make([]bool, len(describeStats))
+	stats[statCount], valid[statCount] = float64(numop.Count(x)), true
+	stats[statNullCount], valid[statNullCount] = float64(numop.CountNull(x)), true
+	stats[statMean], valid[statMean] = numop.Mean(x)
+	stats[statStd], valid[statStd] = numop.Std(x, 1)
+	stats[statMin], valid[statMin] = numop.Min(x)
+	stats[statMax], valid[statMax] = numop.Max(x)
+	if qs, ok := numop.Quantiles(x, 0.25, 0.5, 0.75); ok {
+		copy(stats[stat25:stat75+1], qs)
+		valid[stat25], valid[stat50], valid[stat75] = true, true, true
+	}
+	return stats, valid, nil
+}
+
+// describeValues returns the statistics of a string or bool vector, as strings
+func describeValues(v vector.Vector) (vector.Vector, error) {
+	values, counts, err := hashop.ValueCounts(v)
+	if err != nil {
+		return nil, err
+	}
+
+	strs := make([]string, len(describeStats))
+	valid := make([]bool, len(describeStats))
+	set := func(i int, s string) {
+		strs[i], valid[i] = s, true
+	}
+	set(statCount, strconv.Itoa(v.Len()-v.NullCount()))
+	set(statNullCount, strconv.Itoa(v.NullCount()))
+	nUnique := values.Len()
+	if v.NullCount() > 0 {
+		nUnique--
+	}
+	set(statNUnique, strconv.Itoa(nUnique))
+
+	// value counts are sorted by count; the top element is the first non-null one
+	for i := 0; i < values.Len(); i++ {
+		if values.IsNull(i) {
+			continue
+		}
+		switch x := values.(type) {
+		case *vector.StringVector:
+			set(statTop, x.StringValAt(i))
+		case *vector.BoolVector:
+			set(statTop, strconv.FormatBool(x.ValAt(i)))
+		default:
+			return nil, fmt.Errorf("Describe not supported for column of type %v", v.Type())
+		}
+		set(statFreq, strconv.FormatInt(counts.Data()[i], 10))
+		break
+	}
+	return vector.StringVecFromStrings(strs, valid), nil
+}
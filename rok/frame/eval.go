@@ -0,0 +1,657 @@
+package frame
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/boolop"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+const secsInOneDay int64 = 60 * 60 * 24
+
+// default layout for string literals compared against date columns
+const dateLitLayout = "2006-01-02"
+
+// exprFunc describes a function call node in an expression tree
+type exprFunc struct {
+	name    string
+	params  []any                                             // non-expression parameters; used for display only
+	outType func(in []dtype.DataType) (dtype.DataType, error) // output type, given argument types
+	eval    func(in []vector.Vector) (vector.Vector, error)   // evaluation, given argument vectors
+}
+
+// Eval evaluates an expression against the Frame, returning a new vector.
+//
+// The expression is type-checked against the Frame's column types before any evaluation takes place.
+func (f *Frame) Eval(e ColExpr) (vector.Vector, error) {
+	if _, err := f.exprType(e); err != nil {
+		return nil, err
+	}
+	return f.eval(e)
+}
+
+// exprType type-checks an expression, returning the DataType it evaluates to
+func (f *Frame) exprType(e ColExpr) (dtype.DataType, error) {
+	switch e.kind {
+	case colExpr:
+		colIdx, ok := f.NameColMap[e.column()]
+		if !ok {
+			return nil, fmt.Errorf("Column '%s' not recognized", e.column())
+		}
+		return f.Cols[colIdx].DType, nil
+
+	case litExpr:
+		return litType(e.lit)
+
+	case unaryExpr:
+		t, err := f.exprType(e.args[0])
+		if err != nil {
+			return nil, err
+		}
+		return unaryType(e, t)
+
+	case binaryExpr:
+		lhs, rhs := e.args[0], e.args[1]
+		lt, err := f.exprType(lhs)
+		if err != nil {
+			return nil, err
+		}
+		rt, err := f.exprType(rhs)
+		if err != nil {
+			return nil, err
+		}
+		// a literal operand takes on the type of the other operand
+		if rhs.kind == litExpr && lhs.kind != litExpr {
+			if rt, err = litTypeAs(rhs.lit, lt); err != nil {
+				return nil, fmt.Errorf("%s: %w", e, err)
+			}
+		}
+		if lhs.kind == litExpr && rhs.kind != litExpr {
+			if lt, err = litTypeAs(lhs.lit, rt); err != nil {
+				return nil, fmt.Errorf("%s: %w", e, err)
+			}
+		}
+		return binaryType(e, lt, rt)
+
+	case funcExpr:
+		argTypes := make([]dtype.DataType, len(e.args))
+		for i, a := range e.args {
+			t, err := f.exprType(a)
+			if err != nil {
+				return nil, err
+			}
+			argTypes[i] = t
+		}
+		t, err := e.fn.outType(argTypes)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e, err)
+		}
+		return t, nil
+
+	case aggExpr:
+		return nil, fmt.Errorf("aggregation %s is only supported in GroupBy.Agg, or over a window", e)
+
+	case windowExpr:
+		return f.windowType(e)
+	}
+	return nil, fmt.Errorf("invalid expression %s", e)
+}
+
+func unaryType(e ColExpr, t dtype.DataType) (dtype.DataType, error) {
+	switch e.op {
+	case opNot:
+		if t.Type() != dtype.BOOL {
+			return nil, fmt.Errorf("%s: operand must be bool, got %v", e, t)
+		}
+		return t, nil
+	case opNeg:
+		if !dtype.IsSignedInteger(t.Type()) && !dtype.IsFloat(t.Type()) {
+			return nil, fmt.Errorf("%s: operand must be signed numeric, got %v", e, t)
+		}
+		return t, nil
+	}
+	return nil, fmt.Errorf("%s: invalid unary operator", e)
+}
+
+func binaryType(e ColExpr, lt, rt dtype.DataType) (dtype.DataType, error) {
+	// mixed numeric types are promoted to a common type
+	if dtype.IsNumeric(lt.Type()) && dtype.IsNumeric(rt.Type()) {
+		t, err := numop.PromoteTypes(lt, rt)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e, err)
+		}
+		lt, rt = t, t
+	}
+	if lt.Type() != rt.Type() {
+		return nil, fmt.Errorf("%s: mismatched operand types %v and %v", e, lt, rt)
+	}
+	switch e.op {
+	case opAdd, opSub, opMul, opDiv, opMod, opFloorDiv:
+		if !dtype.IsNumeric(lt.Type()) {
+			return nil, fmt.Errorf("%s: operands must be numeric, got %v", e, lt)
+		}
+		return lt, nil
+	case opEq, opNe, opGt, opLt, opGe, opLe:
+		return dtype.Bool{}, nil
+	case opAnd, opOr, opXor, opAndNot:
+		if lt.Type() != dtype.BOOL {
+			return nil, fmt.Errorf("%s: operands must be bool, got %v", e, lt)
+		}
+		return lt, nil
+	}
+	return nil, fmt.Errorf("%s: invalid binary operator", e)
+}
+
+// litType returns the default DataType of a literal value
+func litType(lit any) (dtype.DataType, error) {
+	switch lit.(type) {
+	case int, int64:
+		return dtype.Int64{}, nil
+	case int32:
+		return dtype.Int32{}, nil
+	case int16:
+		return dtype.Int16{}, nil
+	case int8:
+		return dtype.Int8{}, nil
+	case uint, uint64:
+		return dtype.UInt64{}, nil
+	case uint32:
+		return dtype.UInt32{}, nil
+	case uint16:
+		return dtype.UInt16{}, nil
+	case uint8:
+		return dtype.UInt8{}, nil
+	case float64:
+		return dtype.Float64{}, nil
+	case float32:
+		return dtype.Float32{}, nil
+	case string, []byte:
+		return dtype.String{}, nil
+	case bool:
+		return dtype.Bool{}, nil
+	case time.Time:
+		return dtype.Date{}, nil
+	}
+	return nil, fmt.Errorf("unsupported literal %v of type %T", lit, lit)
+}
+
+// litTypeAs returns the DataType a literal takes on, as the operand of a binary operation with type `to`.
+//
+// A numeric literal takes on type `to` if it can be represented exactly as `to`, and otherwise keeps its
+// default type, to be promoted with `to` (e.g. 30.5 against an int32 column promotes both to float64).
+func litTypeAs(lit any, to dtype.DataType) (dtype.DataType, error) {
+	from, err := litType(lit)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case dtype.IsNumeric(to.Type()) && dtype.IsNumeric(from.Type()):
+		if !numericLitFits(lit, to.Type()) {
+			return from, nil
+		}
+		return to, nil
+	case to.Type() == dtype.DATE && from.Type() == dtype.STRING:
+		if _, err := dateLit(lit); err != nil {
+			return nil, err
+		}
+		return to, nil
+	}
+	// no conversion; leave to the operator to report mismatches
+	return from, nil
+}
+
+// unifyTypes returns the common type of values of types t and u: numeric types are promoted to a common
+// type (see numop.PromoteTypes), and other types must match
+func unifyTypes(t, u dtype.DataType) (dtype.DataType, error) {
+	if dtype.IsNumeric(t.Type()) && dtype.IsNumeric(u.Type()) {
+		return numop.PromoteTypes(t, u)
+	}
+	if t.Type() != u.Type() {
+		return nil, fmt.Errorf("mismatched types %v and %v", t, u)
+	}
+	return t, nil
+}
+
+// numericLitFits returns whether a numeric literal can be represented exactly as type `to`
+func numericLitFits(lit any, to dtype.LogicalType) bool {
+	if dtype.IsFloat(to) {
+		return true
+	}
+	var lo, hi float64
+	switch to {
+	case dtype.INT8:
+		lo, hi = math.MinInt8, math.MaxInt8
+	case dtype.INT16:
+		lo, hi = math.MinInt16, math.MaxInt16
+	case dtype.INT32:
+		lo, hi = math.MinInt32, math.MaxInt32
+	case dtype.INT64:
+		lo, hi = math.MinInt64, math.MaxInt64
+	case dtype.UINT8:
+		lo, hi = 0, math.MaxUint8
+	case dtype.UINT16:
+		lo, hi = 0, math.MaxUint16
+	case dtype.UINT32:
+		lo, hi = 0, math.MaxUint32
+	case dtype.UINT64:
+		lo, hi = 0, math.MaxUint64
+	}
+	// 64-bit bounds are not exact as float64
+	switch v := lit.(type) {
+	case uint:
+		return to == dtype.UINT64 || (to == dtype.INT64 && v <= math.MaxInt64) || fitsFloatRange(float64(v), lo, hi)
+	case uint64:
+		return to == dtype.UINT64 || (to == dtype.INT64 && v <= math.MaxInt64) || fitsFloatRange(float64(v), lo, hi)
+	case int:
+		return to == dtype.INT64 || (to == dtype.UINT64 && v >= 0) || fitsFloatRange(float64(v), lo, hi)
+	case int64:
+		return to == dtype.INT64 || (to == dtype.UINT64 && v >= 0) || fitsFloatRange(float64(v), lo, hi)
+	}
+	f, _ := litAsFloat64(lit)
+	return f == math.Trunc(f) && fitsFloatRange(f, lo, hi)
+}
+
+func fitsFloatRange(f, lo, hi float64) bool {
+	return f >= lo && f <= hi
+}
+
+// litAsFloat64 converts a numeric literal to a float64
+func litAsFloat64(lit any) (float64, bool) {
+	switch v := lit.(type) {
+	case int:
+		return float64(v), true
+	case int8:
+		return float64(v), true
+	case int16:
+		return float64(v), true
+	case int32:
+		return float64(v), true
+	case int64:
+		return float64(v), true
+	case uint:
+		return float64(v), true
+	case uint8:
+		return float64(v), true
+	case uint16:
+		return float64(v), true
+	case uint32:
+		return float64(v), true
+	case uint64:
+		return float64(v), true
+	case float32:
+		return float64(v), true
+	case float64:
+		return v, true
+	}
+	return 0, false
+}
+
+// numericLitAs converts a numeric literal to type T; assumes the literal has been type-checked
+func numericLitAs[T vector.Numeric](lit any) T {
+	switch v := lit.(type) {
+	case int:
+		return T(v)
+	case int8:
+		return T(v)
+	case int16:
+		return T(v)
+	case int32:
+		return T(v)
+	case int64:
+		return T(v)
+	case uint:
+		return T(v)
+	case uint8:
+		return T(v)
+	case uint16:
+		return T(v)
+	case uint32:
+		return T(v)
+	case uint64:
+		return T(v)
+	case float32:
+		return T(v)
+	case float64:
+		return T(v)
+	}
+	return 0
+}
+
+// dateLit converts a date literal (time.Time, or string in YYYY-MM-DD format) to days since Unix epoch; a
+// time.Time gives its calendar date, in its location
+func dateLit(lit any) (int32, error) {
+	switch v := lit.(type) {
+	case time.Time:
+		year, month, day := v.Date()
+		return int32(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / secsInOneDay), nil
+	case string:
+		d, err := time.Parse(dateLitLayout, v)
+		if err != nil {
+			return 0, fmt.Errorf("literal %q cannot be represented as date: %w", v, err)
+		}
+		return int32(d.Unix() / secsInOneDay), nil
+	}
+	return 0, fmt.Errorf("literal %v cannot be represented as date", lit)
+}
+
+// eval evaluates a type-checked expression
+func (f *Frame) eval(e ColExpr) (vector.Vector, error) {
+	switch e.kind {
+	case colExpr:
+		return f.Cols[f.NameColMap[e.column()]].Vec, nil
+
+	case litExpr:
+		t, err := litType(e.lit)
+		if err != nil {
+			return nil, err
+		}
+		return broadcastLit(e.lit, t, f.Height())
+
+	case unaryExpr:
+		x, err := f.eval(e.args[0])
+		if err != nil {
+			return nil, err
+		}
+		return evalUnary(e.op, x)
+
+	case binaryExpr:
+		lhs, rhs := e.args[0], e.args[1]
+		// literal-literal; broadcast lhs, rhs follows
+		if lhs.kind == litExpr && rhs.kind == litExpr {
+			x, err := f.eval(lhs)
+			if err != nil {
+				return nil, err
+			}
+			y, err := broadcastLitAs(rhs.lit, x.Type(), x.Len())
+			if err != nil {
+				return nil, err
+			}
+			return evalBinary(e.op, x, y)
+		}
+		if lhs.kind == litExpr {
+			y, err := f.eval(rhs)
+			if err != nil {
+				return nil, err
+			}
+			x, err := broadcastLitAs(lhs.lit, y.Type(), y.Len())
+			if err != nil {
+				return nil, err
+			}
+			return evalBinary(e.op, x, y)
+		}
+		x, err := f.eval(lhs)
+		if err != nil {
+			return nil, err
+		}
+		if rhs.kind == litExpr {
+			return evalBinaryLit(e.op, x, rhs.lit)
+		}
+		y, err := f.eval(rhs)
+		if err != nil {
+			return nil, err
+		}
+		return evalBinary(e.op, x, y)
+
+	case funcExpr:
+		args := make([]vector.Vector, len(e.args))
+		for i, a := range e.args {
+			v, err := f.eval(a)
+			if err != nil {
+				return nil, err
+			}
+			args[i] = v
+		}
+		return e.fn.eval(args)
+
+	case windowExpr:
+		return f.evalWindow(e)
+	}
+	return nil, fmt.Errorf("invalid expression %s", e)
+}
+
+// broadcastLitAs returns a vector of length n, with each element set to the literal, typed as the
+// operand of a binary operation with type `other` (see litTypeAs)
+func broadcastLitAs(lit any, other dtype.DataType, n int) (vector.Vector, error) {
+	t, err := litTypeAs(lit, other)
+	if err != nil {
+		return nil, err
+	}
+	return broadcastLit(lit, t, n)
+}
+
+// broadcastLit returns a vector of length n, with each element set to the literal, as DataType `to`
+func broadcastLit(lit any, to dtype.DataType, n int) (vector.Vector, error) {
+	valid := vector.NewValidityBitMap(n)
+	switch to.Type() {
+	case dtype.UINT8:
+		return fillNumeric(numericLitAs[uint8](lit), to, valid), nil
+	case dtype.UINT16:
+		return fillNumeric(numericLitAs[uint16](lit), to, valid), nil
+	case dtype.UINT32:
+		return fillNumeric(numericLitAs[uint32](lit), to, valid), nil
+	case dtype.UINT64:
+		return fillNumeric(numericLitAs[uint64](lit), to, valid), nil
+	case dtype.INT8:
+		return fillNumeric(numericLitAs[int8](lit), to, valid), nil
+	case dtype.INT16:
+		return fillNumeric(numericLitAs[int16](lit), to, valid), nil
+	case dtype.INT32:
+		return fillNumeric(numericLitAs[int32](lit), to, valid), nil
+	case dtype.INT64:
+		return fillNumeric(numericLitAs[int64](lit), to, valid), nil
+	case dtype.FLOAT32:
+		return fillNumeric(numericLitAs[float32](lit), to, valid), nil
+	case dtype.FLOAT64:
+		return fillNumeric(numericLitAs[float64](lit), to, valid), nil
+
+	case dtype.STRING:
+		var s []byte
+		switch v := lit.(type) {
+		case string:
+			s = []byte(v)
+		case []byte:
+			s = v
+		default:
+			return nil, fmt.Errorf("literal %v cannot be represented as %v", lit, to)
+		}
+		data := make([]byte, len(s)*n)
+		offsets := make([]int64, n+1)
+		for i := 0; i < n; i++ {
+			offsets[i] = int64(i * len(s))
+			copy(data[i*len(s):], s)
+		}
+		offsets[n] = int64(len(data))
+		return vector.StringVecFromComponents(data, offsets, valid), nil
+
+	case dtype.BOOL:
+		b, ok := lit.(bool)
+		if !ok {
+			return nil, fmt.Errorf("literal %v cannot be represented as %v", lit, to)
+		}
+		data := make([]byte, valid.Len())
+		if b {
+			copy(data, valid.Buffer)
+		}
+		return vector.BoolVecFromComponenets(to, data, valid), nil
+
+	case dtype.DATE:
+		d, err := dateLit(lit)
+		if err != nil {
+			return nil, err
+		}
+		data := make([]int32, n)
+		for i := range data {
+			data[i] = d
+		}
+		return vector.DateVecFromComponents(data, valid), nil
+	}
+	return nil, fmt.Errorf("literal %v cannot be represented as %v", lit, to)
+}
+
+func fillNumeric[T vector.Numeric](val T, dType dtype.DataType, valid vector.ValidityBitMap) *vector.NumericVector[T] {
+	data := make([]T, valid.TrueLen)
+	for i := range data {
+		data[i] = val
+	}
+	return vector.NumericVecFromComponents(dType, data, valid)
+}
+
+// evalUnary dispatches a unary operator to its kernel
+func evalUnary(op exprOp, x vector.Vector) (vector.Vector, error) {
+	switch op {
+	case opNot:
+		return boolop.Not(x.(*vector.BoolVector)), nil
+	case opNeg:
+		switch xv := x.(type) {
+		case *vector.NumericVector[int8]:
+			return numop.MulLit(xv, -1), nil
+		case *vector.NumericVector[int16]:
+			return numop.MulLit(xv, -1), nil
+		case *vector.NumericVector[int32]:
+			return numop.MulLit(xv, -1), nil
+		case *vector.NumericVector[int64]:
+			return numop.MulLit(xv, -1), nil
+		case *vector.NumericVector[int]:
+			return numop.MulLit(xv, -1), nil
+		case *vector.NumericVector[float32]:
+			return numop.MulLit(xv, -1), nil
+		case *vector.NumericVector[float64]:
+			return numop.MulLit(xv, -1), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported operand %v for unary operator %s", x.Type(), exprOpSymbols[op])
+}
+
+// evalBinary dispatches a binary operator to its kernel, given two vectors of the same type
+func evalBinary(op exprOp, x, y vector.Vector) (vector.Vector, error) {
+	// numeric operands may differ in type; numop promotes them to a common type
+	if dtype.IsNumeric(x.Type().Type()) && dtype.IsNumeric(y.Type().Type()) {
+		if fn, ok := numericOps[op]; ok {
+			return fn(x, y)
+		}
+		if fn, ok := numericCmps[op]; ok {
+			return fn(x, y)
+		}
+	}
+
+	switch xv := x.(type) {
+	case *vector.StringVector:
+		if yv, ok := y.(*vector.StringVector); ok && isComparison(op) {
+			return strCmpVec[op](xv, yv), nil
+		}
+
+	case *vector.DateVector:
+		if yv, ok := y.(*vector.DateVector); ok && isComparison(op) {
+			return dateCmpVec[op](xv, yv), nil
+		}
+
+	case *vector.BoolVector:
+		yv, ok := y.(*vector.BoolVector)
+		if !ok {
+			break
+		}
+		switch {
+		case op == opAnd:
+			return boolop.And(xv, yv), nil
+		case op == opOr:
+			return boolop.Or(xv, yv), nil
+		case op == opXor:
+			return boolop.Xor(xv, yv), nil
+		case op == opAndNot:
+			return boolop.AndNot(xv, yv), nil
+		case isComparison(op):
+			return boolCmpVec[op](xv, yv), nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported operands %v and %v for operator %s", x.Type(), y.Type(), exprOpSymbols[op])
+}
+
+// evalBinaryLit dispatches a binary operator to its kernel, given a vector and literal
+func evalBinaryLit(op exprOp, x vector.Vector, lit any) (vector.Vector, error) {
+	// literals that don't fit the vector's type are broadcast, and promoted alongside it
+	if dtype.IsNumeric(x.Type().Type()) && !numericLitFits(lit, x.Type().Type()) {
+		y, err := broadcastLitAs(lit, x.Type(), x.Len())
+		if err != nil {
+			return nil, err
+		}
+		return evalBinary(op, x, y)
+	}
+
+	switch xv := x.(type) {
+	case *vector.NumericVector[uint8]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[uint16]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[uint32]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[uint64]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[int8]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[int16]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[int32]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[int64]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[int]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[float32]:
+		return numericBinaryLit(op, xv, lit)
+	case *vector.NumericVector[float64]:
+		return numericBinaryLit(op, xv, lit)
+
+	case *vector.StringVector:
+		if s, ok := lit.(string); ok && isComparison(op) {
+			return strCmpLit[op](xv, []byte(s)), nil
+		}
+		if s, ok := lit.([]byte); ok && isComparison(op) {
+			return strCmpLit[op](xv, s), nil
+		}
+
+	case *vector.DateVector:
+		if isComparison(op) {
+			d, err := dateLit(lit)
+			if err != nil {
+				return nil, err
+			}
+			return dateCmpLit[op](xv, d), nil
+		}
+
+	case *vector.BoolVector:
+		if b, ok := lit.(bool); ok && isComparison(op) {
+			return boolCmpLit[op](xv, b), nil
+		}
+	}
+	y, err := broadcastLitAs(lit, x.Type(), x.Len())
+	if err != nil {
+		return nil, err
+	}
+	return evalBinary(op, x, y)
+}
+
+func numericBinaryLit[T vector.Numeric](op exprOp, x *vector.NumericVector[T], lit any) (vector.Vector, error) {
+	l := numericLitAs[T](lit)
+	switch op {
+	case opAdd:
+		return numop.AddLit(x, l), nil
+	case opSub:
+		return numop.SubLit(x, l), nil
+	case opMul:
+		return numop.MulLit(x, l), nil
+	case opDiv:
+		return numop.CheckedDivLit(x, l, compute.NullOnFail)
+	case opMod:
+		return numop.CheckedModLit(x, l, compute.NullOnFail)
+	case opFloorDiv:
+		return numop.CheckedFloorDivLit(x, l, compute.NullOnFail)
+	case opEq, opNe, opGt, opLt, opGe, opLe:
+		return numericCmpLit(op, x, l), nil
+	}
+	return nil, fmt.Errorf("unsupported operand %v for operator %s", x.Type(), exprOpSymbols[op])
+}
@@ -0,0 +1,240 @@
+package frame
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/dateop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// FormatOptions configures the rendering of a Frame as a table (see Format)
+type FormatOptions struct {
+	MaxRows        int  // maximum number of rows shown, split between the head and tail of the Frame; all rows if <= 0
+	MaxColWidth    int  // maximum width of a column, in runes; longer cells are truncated with "…". No limit if <= 0
+	FloatPrecision int  // number of digits after the decimal point of floats, if FixedPrecision
+	FixedPrecision bool // format floats with FloatPrecision digits; otherwise with their shortest exact representation
+}
+
+// DefaultFormatOptions are the options used by String
+var DefaultFormatOptions = FormatOptions{MaxRows: 10, MaxColWidth: 32}
+
+const (
+	nullMarker = "null"
+	ellipsis   = "…"
+)
+
+// String renders the Frame as a table, with DefaultFormatOptions (see Format)
+func (f *Frame) String() string {
+	return f.Format(DefaultFormatOptions)
+}
+
+// Format renders the Frame as a box-drawn table, below its shape; the header holds each column's name and
+// DataType:
+//
+//	shape: (3, 2)
+//	┌───────┬────────────┐
+//	│    id │ joined     │
+//	│ int64 │ date       │
+//	├───────┼────────────┤
+//	│     1 │ 2020-01-02 │
+//	│     … │ …          │
+//	│     3 │ null       │
+//	└───────┴────────────┘
+//
+// Numeric columns are right-aligned; null elements are shown as null, and dates as YYYY-MM-DD. Frames with
+// more than opts.MaxRows rows show their head and tail, separated by a row of "…".
+func (f *Frame) Format(opts FormatOptions) string {
+	n := f.Height()
+	var b strings.Builder
+	fmt.Fprintf(&b, "shape: (%d, %d)", n, len(f.Cols))
+	if len(f.Cols) == 0 {
+		return b.String()
+	}
+
+	// the rows shown; -1 marks the ellipsis row
+	rows := make([]int, 0, min(n, max(opts.MaxRows, 0)+1))
+	if opts.MaxRows <= 0 || n <= opts.MaxRows {
+		for i := 0; i < n; i++ {
+			rows = append(rows, i)
+		}
+	} else {
+		head, tail := (opts.MaxRows+1)/2, opts.MaxRows/2
+		for i := 0; i < head; i++ {
+			rows = append(rows, i)
+		}
+		rows = append(rows, -1)
+		for i := n - tail; i < n; i++ {
+			rows = append(rows, i)
+		}
+	}
+
+	// cells of each column: name, type, then each row shown
+	cells := make([][]string, len(f.Cols))
+	widths := make([]int, len(f.Cols))
+	rightAlign := make([]bool, len(f.Cols))
+	floatPrecision := -1
+	if opts.FixedPrecision {
+		floatPrecision = max(opts.FloatPrecision, 0)
+	}
+	for c, col := range f.Cols {
+		format := cellFormatter(col.Vec, floatPrecision)
+		colCells := make([]string, 0, len(rows)+2)
+		colCells = append(colCells, col.Name, fmt.Sprint(col.DType))
+		for _, i := range rows {
+			switch {
+			case i == -1:
+				colCells = append(colCells, ellipsis)
+			case col.Vec.IsNull(i):
+				colCells = append(colCells, nullMarker)
+			default:
+				colCells = append(colCells, format(i))
+			}
+		}
+		for k, s := range colCells {
+			s = truncate(escapeControl(s), opts.MaxColWidth)
+			colCells[k] = s
+			widths[c] = max(widths[c], utf8.RuneCountInString(s))
+		}
+		cells[c] = colCells
+		rightAlign[c] = dtype.IsNumeric(col.DType.Type())
+	}
+
+	border := func(left, mid, right string) {
+		b.WriteString("\n" + left)
+		for c, w := range widths {
+			if c > 0 {
+				b.WriteString(mid)
+			}
+			b.WriteString(strings.Repeat("─", w+2))
+		}
+		b.WriteString(right)
+	}
+	line := func(k int) {
+		b.WriteString("\n│")
+		for c, w := range widths {
+			if c > 0 {
+				b.WriteString("│")
+			}
+			s := cells[c][k]
+			pad := strings.Repeat(" ", w-utf8.RuneCountInString(s))
+			if rightAlign[c] {
+				b.WriteString(" " + pad + s + " ")
+			} else {
+				b.WriteString(" " + s + pad + " ")
+			}
+		}
+		b.WriteString("│")
+	}
+
+	border("┌", "┬", "┐")
+	line(0)
+	line(1)
+	border("├", "┼", "┤")
+	for k := 2; k < len(rows)+2; k++ {
+		line(k)
+	}
+	border("└", "┴", "┘")
+	return b.String()
+}
+
+// cellFormatter returns a function formatting the non-null element i of v
+func cellFormatter(v vector.Vector, floatPrecision int) func(i int) string {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[uint16]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[uint32]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[uint64]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[int8]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[int16]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[int32]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[int64]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[int]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[float32]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.NumericVector[float64]:
+		return numericFormatter(x, floatPrecision)
+	case *vector.StringVector:
+		return x.StringValAt
+	case *vector.BoolVector:
+		return func(i int) string { return strconv.FormatBool(x.ValAt(i)) }
+	case *vector.DateVector:
+		return func(i int) string { return dateop.FormatDate(x.Data()[i], dateLitLayout) }
+	}
+	return func(i int) string { return "?" }
+}
+
+func numericFormatter[T vector.Numeric](x *vector.NumericVector[T], floatPrecision int) func(i int) string {
+	data := x.Data()
+	switch {
+	case compute.IsFloat[T]():
+		bits := x.Type().BitsReq()
+		return func(i int) string { return formatFloat(float64(data[i]), floatPrecision, bits) }
+	case compute.IsSigned[T]():
+		return func(i int) string { return strconv.FormatInt(int64(data[i]), 10) }
+	}
+	return func(i int) string { return strconv.FormatUint(uint64(data[i]), 10) }
+}
+
+// formatFloat formats a float with prec digits after the decimal point; if prec < 0, with the shortest
+// representation, in scientific notation for very large or small magnitudes, and with at least one decimal
+func formatFloat(v float64, prec, bits int) string {
+	if prec >= 0 {
+		return strconv.FormatFloat(v, 'f', prec, bits)
+	}
+	if abs := math.Abs(v); abs >= 1e16 || (abs != 0 && abs < 1e-6) {
+		return strconv.FormatFloat(v, 'e', -1, bits)
+	}
+	s := strconv.FormatFloat(v, 'f', -1, bits)
+	if !strings.ContainsAny(s, ".NI") {
+		s += ".0"
+	}
+	return s
+}
+
+// truncate cuts s to width runes, ending with "…"; s is left as-is if width <= 0
+func truncate(s string, width int) string {
+	if width <= 0 || utf8.RuneCountInString(s) <= width {
+		return s
+	}
+	runes := []rune(s)
+	return string(runes[:width-1]) + ellipsis
+}
+
+// escapeControl escapes control characters, which would otherwise break the table's lines
+func escapeControl(s string) string {
+	if strings.IndexFunc(s, unicode.IsControl) == -1 {
+		return s
+	}
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case unicode.IsControl(r):
+			fmt.Fprintf(&b, `\x%02x`, r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
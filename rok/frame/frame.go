@@ -2,33 +2,244 @@ package frame
 
 import (
 	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
 )
 
+// Frame represents a table of named columns of equal length.
+//
+// Frames built with New hold unique column names, indexed by NameColMap. Frame methods return new Frames;
+// unchanged columns share their vectors, which are never modified in place.
 type Frame struct {
 	Cols       []*Column
 	NameColMap map[string]int
 }
 
+// New returns a Frame of the given columns; columns must be of equal length, with unique names, and hold
+// vectors of their DataType
+func New(cols ...*Column) (*Frame, error) {
+	for _, col := range cols {
+		if col == nil || col.Vec == nil || col.DType == nil {
+			return nil, fmt.Errorf("Column '%s' must hold a vector and its DataType", colName(col))
+		}
+		if col.Vec.Type().Type() != col.DType.Type() {
+			return nil, fmt.Errorf("Column '%s' of type %v holds a vector of type %v", col.Name, col.DType, col.Vec.Type())
+		}
+		if n := cols[0].Vec.Len(); col.Vec.Len() != n {
+			return nil, fmt.Errorf("Column '%s' has length %d, expected %d", col.Name, col.Vec.Len(), n)
+		}
+	}
+	return fromCols(cols)
+}
+
+// colName returns the name of a possibly nil column, for error messages
+func colName(col *Column) string {
+	if col == nil {
+		return "<nil>"
+	}
+	return col.Name
+}
+
+// fromCols returns a Frame of columns of equal length, checking that their names are unique
+func fromCols(cols []*Column) (*Frame, error) {
+	nameColMap := make(map[string]int, len(cols))
+	for i, col := range cols {
+		if _, ok := nameColMap[col.Name]; ok {
+			return nil, fmt.Errorf("duplicate column name '%s'; use Alias to rename", col.Name)
+		}
+		nameColMap[col.Name] = i
+	}
+	return &Frame{Cols: cols, NameColMap: nameColMap}, nil
+}
+
+// Height returns the number of rows in the Frame
+func (f *Frame) Height() int {
+	if len(f.Cols) == 0 {
+		return 0
+	}
+	return f.Cols[0].Vec.Len()
+}
+
+// Width returns the number of columns in the Frame
+func (f *Frame) Width() int {
+	return len(f.Cols)
+}
+
+// Column returns the column `name`
+func (f *Frame) Column(name string) (*Column, error) {
+	colIdx, ok := f.NameColMap[name]
+	if !ok {
+		return nil, fmt.Errorf("Column '%s' not recognized", name)
+	}
+	return f.Cols[colIdx], nil
+}
+
+// Select returns a new Frame of the results of each expression, named by the expression (see Alias), e.g.
+//
+//	f.Select(Col("a"), Col("a").Alias("b"), Col("c").Mul(2).Alias("c2"))
+//
+// Column references share the vectors of the Frame; literal expressions are broadcast to the Frame's height.
+// All expressions are type-checked before any evaluation takes place.
 func (f *Frame) Select(c ...ColExpr) (*Frame, error) {
-	// return new frame, deep copy columns
+	colTypes := make([]dtype.DataType, len(c))
+	for i, e := range c {
+		t, err := f.exprType(e)
+		if err != nil {
+			return nil, err
+		}
+		colTypes[i] = t
+	}
+
 	newCols := make([]*Column, len(c))
-	newNameColMap := make(map[string]int)
+	for i, e := range c {
+		vec, err := f.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		newCols[i] = &Column{Name: e.Name, DType: colTypes[i], Vec: vec}
+	}
+	return fromCols(newCols)
+}
+
+// WithColumn returns a new Frame with the result of an expression as the column `name`, replacing the
+// column of that name if the Frame holds one, and appending it otherwise
+func (f *Frame) WithColumn(name string, e ColExpr) (*Frame, error) {
+	t, err := f.exprType(e)
+	if err != nil {
+		return nil, err
+	}
+	vec, err := f.eval(e)
+	if err != nil {
+		return nil, err
+	}
+
+	newCols := f.copyCols()
+	newCol := &Column{Name: name, DType: t, Vec: vec}
+	if colIdx, ok := f.NameColMap[name]; ok {
+		newCols[colIdx] = newCol
+	} else {
+		newCols = append(newCols, newCol)
+	}
+	return fromCols(newCols)
+}
 
-	for i, col := range c {
-		colIdx, ok := f.NameColMap[col.Name]
-		// column not in Frame
-		if !ok {
-			return nil, fmt.Errorf("Column '%s' not recognized", col.Name)
+// Drop returns a new Frame without the given columns
+func (f *Frame) Drop(names ...string) (*Frame, error) {
+	dropped := make(map[string]bool, len(names))
+	for _, name := range names {
+		if _, ok := f.NameColMap[name]; !ok {
+			return nil, fmt.Errorf("Column '%s' not recognized", name)
 		}
+		dropped[name] = true
+	}
+
+	newCols := make([]*Column, 0, len(f.Cols)-len(dropped))
+	for _, col := range f.copyCols() {
+		if !dropped[col.Name] {
+			newCols = append(newCols, col)
+		}
+	}
+	return fromCols(newCols)
+}
+
+// Rename returns a new Frame, renaming columns from the keys of `names` to their values; names may be
+// swapped, but must remain unique
+func (f *Frame) Rename(names map[string]string) (*Frame, error) {
+	for old := range names {
+		if _, ok := f.NameColMap[old]; !ok {
+			return nil, fmt.Errorf("Column '%s' not recognized", old)
+		}
+	}
+
+	newCols := f.copyCols()
+	newNameColMap := make(map[string]int, len(newCols))
+	for i, col := range newCols {
+		if name, ok := names[col.Name]; ok {
+			col.Name = name
+		}
+		if _, ok := newNameColMap[col.Name]; ok {
+			return nil, fmt.Errorf("duplicate column name '%s' after Rename", col.Name)
+		}
+		newNameColMap[col.Name] = i
+	}
+	return &Frame{Cols: newCols, NameColMap: newNameColMap}, nil
+}
+
+// Head returns a new Frame of the first n rows; n is clamped to [0, Height]
+func (f *Frame) Head(n int) (*Frame, error) {
+	return f.Slice(0, n)
+}
+
+// Tail returns a new Frame of the last n rows; n is clamped to [0, Height]
+func (f *Frame) Tail(n int) (*Frame, error) {
+	h := f.Height()
+	n = min(max(n, 0), h)
+	return f.Slice(h-n, n)
+}
+
+// Slice returns a new Frame of `length` rows, starting at row `offset`; a negative offset counts from the
+// end of the Frame. The rows are clamped to the bounds of the Frame.
+func (f *Frame) Slice(offset, length int) (*Frame, error) {
+	h := f.Height()
+	if offset < 0 {
+		offset = max(h+offset, 0)
+	}
+	start := min(offset, h)
+	end := min(start+max(length, 0), h)
+
+	indices := make([]int, end-start)
+	for i := range indices {
+		indices[i] = start + i
+	}
+	return f.take(indices)
+}
+
+// Filter returns a new Frame, keeping the rows where a boolean predicate expression is true.
+//
+// Rows where the predicate evaluates to null are dropped.
+func (f *Frame) Filter(pred ColExpr) (*Frame, error) {
+	t, err := f.exprType(pred)
+	if err != nil {
+		return nil, err
+	}
+	if t.Type() != dtype.BOOL {
+		return nil, fmt.Errorf("Filter predicate %s must be bool, got %v", pred, t)
+	}
 
-		vec := f.Cols[colIdx].Vec.DeepCopy()
-		selectedCol := Column{
+	mask, err := f.eval(pred)
+	if err != nil {
+		return nil, err
+	}
+	return f.take(selop.MaskIndices(mask.(*vector.BoolVector)))
+}
+
+// copyCols returns copies of the Frame's columns, sharing their vectors
+func (f *Frame) copyCols() []*Column {
+	newCols := make([]*Column, len(f.Cols))
+	for i, col := range f.Cols {
+		newCol := *col
+		newCols[i] = &newCol
+	}
+	return newCols
+}
+
+// take returns a new Frame, gathering the rows of every column at the given indices (see selop.Take)
+func (f *Frame) take(indices []int) (*Frame, error) {
+	newCols := make([]*Column, len(f.Cols))
+	newNameColMap := make(map[string]int)
+	for i, col := range f.Cols {
+		vec, err := selop.Take(col.Vec, indices)
+		if err != nil {
+			return nil, err
+		}
+		newCols[i] = &Column{
 			Name:  col.Name,
-			DType: f.Cols[colIdx].DType,
+			DType: col.DType,
 			Vec:   vec,
 		}
-		newCols[i] = &selectedCol
-		newNameColMap[selectedCol.Name] = i
+		newNameColMap[col.Name] = i
 	}
 	return &Frame{Cols: newCols, NameColMap: newNameColMap}, nil
 }
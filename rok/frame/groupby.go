@@ -0,0 +1,190 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/dateop"
+	"github.com/rhawrami/rok-frame/rok/compute/hashop"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/compute/strop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// GroupBy represents a Frame grouped by one or more key expressions, to be aggregated with Agg
+type GroupBy struct {
+	f    *Frame
+	keys []ColExpr
+}
+
+// GroupBy groups the rows of the Frame by the values of one or more key expressions.
+//
+// Keys may be of any type; null keys form their own group.
+func (f *Frame) GroupBy(keys ...ColExpr) *GroupBy {
+	return &GroupBy{f: f, keys: keys}
+}
+
+// Agg returns a new Frame with one row per group, in order of each group's first row; the columns are the
+// keys, followed by the result of each aggregation expression (e.g. Col("x").Sum().Alias("x_sum")).
+func (g *GroupBy) Agg(aggs ...ColExpr) (*Frame, error) {
+	f := g.f
+	if len(g.keys) == 0 {
+		return nil, fmt.Errorf("GroupBy requires at least one key")
+	}
+
+	// type-check everything before any evaluation
+	colTypes := make([]dtype.DataType, 0, len(g.keys)+len(aggs))
+	for _, k := range g.keys {
+		t, err := f.exprType(k)
+		if err != nil {
+			return nil, err
+		}
+		colTypes = append(colTypes, t)
+	}
+	for _, a := range aggs {
+		t, err := f.aggType(a)
+		if err != nil {
+			return nil, err
+		}
+		colTypes = append(colTypes, t)
+	}
+	newNameColMap := make(map[string]int)
+	for i, e := range append(append([]ColExpr{}, g.keys...), aggs...) {
+		if _, ok := newNameColMap[e.Name]; ok {
+			return nil, fmt.Errorf("duplicate column name '%s'; use Alias to rename", e.Name)
+		}
+		newNameColMap[e.Name] = i
+	}
+
+	keyVecs := make([]vector.Vector, len(g.keys))
+	for i, k := range g.keys {
+		v, err := f.eval(k)
+		if err != nil {
+			return nil, err
+		}
+		keyVecs[i] = v
+	}
+	ids, firstRows, err := hashop.GroupIDs(keyVecs)
+	if err != nil {
+		return nil, err
+	}
+	groups := compute.NewGroups(ids, len(firstRows))
+
+	newCols := make([]*Column, 0, len(colTypes))
+	for i, v := range keyVecs {
+		vec, err := selop.Take(v, firstRows)
+		if err != nil {
+			return nil, err
+		}
+		newCols = append(newCols, &Column{Name: g.keys[i].Name, DType: colTypes[i], Vec: vec})
+	}
+	for i, a := range aggs {
+		v, err := f.eval(a.args[0])
+		if err != nil {
+			return nil, err
+		}
+		vec, err := aggregate(a, v, groups)
+		if err != nil {
+			return nil, err
+		}
+		newCols = append(newCols, &Column{Name: a.Name, DType: colTypes[len(g.keys)+i], Vec: vec})
+	}
+	return &Frame{Cols: newCols, NameColMap: newNameColMap}, nil
+}
+
+// aggType type-checks an aggregation expression, returning the DataType it evaluates to
+func (f *Frame) aggType(e ColExpr) (dtype.DataType, error) {
+	if e.kind != aggExpr {
+		return nil, fmt.Errorf("expected an aggregation, got %s", e)
+	}
+	t, err := f.exprType(e.args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	switch e.agg {
+	case aggCount, aggCountNull:
+		return dtype.Int64{}, nil
+	case aggSum, aggProduct:
+		if dtype.IsNumeric(t.Type()) {
+			return t, nil
+		}
+	case aggMean, aggVar, aggStd:
+		if dtype.IsNumeric(t.Type()) {
+			return dtype.Float64{}, nil
+		}
+	case aggMin, aggMax:
+		if dtype.IsNumeric(t.Type()) || t.Type() == dtype.STRING || t.Type() == dtype.DATE {
+			return t, nil
+		}
+	}
+	return nil, fmt.Errorf("%s: unsupported operand type %v", e, t)
+}
+
+// aggregate dispatches a type-checked aggregation expression to its grouped kernel
+func aggregate(e ColExpr, v vector.Vector, g *compute.Groups) (vector.Vector, error) {
+	switch e.agg {
+	case aggCount:
+		return compute.GroupCount(v, g), nil
+	case aggCountNull:
+		return compute.GroupCountNull(v, g), nil
+	}
+
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[uint16]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[uint32]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[uint64]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[int8]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[int16]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[int32]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[int64]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[int]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[float32]:
+		return aggregateNumeric(e, x, g), nil
+	case *vector.NumericVector[float64]:
+		return aggregateNumeric(e, x, g), nil
+
+	case *vector.StringVector:
+		if e.agg == aggMin {
+			return strop.GroupMin(x, g), nil
+		}
+		return strop.GroupMax(x, g), nil
+
+	case *vector.DateVector:
+		if e.agg == aggMin {
+			return dateop.GroupMin(x, g), nil
+		}
+		return dateop.GroupMax(x, g), nil
+	}
+	return nil, fmt.Errorf("%s: unsupported operand type %v", e, v.Type())
+}
+
+func aggregateNumeric[T vector.Numeric](e ColExpr, x *vector.NumericVector[T], g *compute.Groups) vector.Vector {
+	switch e.agg {
+	case aggSum:
+		return numop.GroupSum(x, g)
+	case aggProduct:
+		return numop.GroupProduct(x, g)
+	case aggMean:
+		return numop.GroupMean(x, g)
+	case aggMin:
+		return numop.GroupMin(x, g)
+	case aggMax:
+		return numop.GroupMax(x, g)
+	case aggVar:
+		return numop.GroupVar(x, g, e.lit.(int))
+	}
+	return numop.GroupStd(x, g, e.lit.(int))
+}
@@ -0,0 +1,282 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/hashop"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// JoinType determines which rows a join keeps
+type JoinType int
+
+const (
+	InnerJoin JoinType = iota // rows with a match on both sides
+	LeftJoin                  // all left rows, with their matching right rows or nulls
+	RightJoin                 // all right rows, with their matching left rows or nulls
+	OuterJoin                 // all rows of both sides, matched where possible
+	SemiJoin                  // left rows with a match, keeping only left columns
+	AntiJoin                  // left rows without a match, keeping only left columns
+)
+
+// default suffix of right column names colliding with left column names
+const defaultJoinSuffix = "_right"
+
+// JoinOn specifies the keys of a join: either On, for keys shared by both frames, or
+// LeftOn and RightOn, matched pairwise
+type JoinOn struct {
+	On      []ColExpr
+	LeftOn  []ColExpr
+	RightOn []ColExpr
+	Suffix  string // appended to right column names colliding with left column names; defaults to "_right"
+}
+
+// Join returns a new Frame, joining the rows of f and other with equal keys; null keys never match.
+//
+// The hash table is built on the keys of the smaller frame, and probed with the other. Rows are ordered
+// by the left frame's rows, then by the right frame's matching rows; for a RightJoin, by the right
+// frame's rows, and for an OuterJoin, unmatched right rows come last.
+//
+// The columns are those of f, followed by those of other; with shared On keys, other's key columns
+// are dropped, except in an OuterJoin (for a RightJoin, the key values come from other).
+// Numeric keys of differing types are promoted to a common type before matching.
+func (f *Frame) Join(other *Frame, on JoinOn, how JoinType) (*Frame, error) {
+	leftOn, rightOn := on.LeftOn, on.RightOn
+	if len(on.On) > 0 {
+		leftOn, rightOn = on.On, on.On
+	}
+	if len(leftOn) == 0 || len(leftOn) != len(rightOn) {
+		return nil, fmt.Errorf("Join requires the same non-zero number of left and right keys, got %d and %d", len(leftOn), len(rightOn))
+	}
+	suffix := on.Suffix
+	if suffix == "" {
+		suffix = defaultJoinSuffix
+	}
+
+	lKeys, rKeys, err := joinKeys(f, other, leftOn, rightOn)
+	if err != nil {
+		return nil, err
+	}
+	li, ri, err := joinIndices(lKeys, rKeys, how)
+	if err != nil {
+		return nil, err
+	}
+
+	// shared key columns appear once
+	sharedKeys := make(map[string]bool)
+	if len(on.On) > 0 && how != OuterJoin {
+		for _, k := range on.On {
+			if k.kind == colExpr {
+				sharedKeys[k.column()] = true
+			}
+		}
+	}
+
+	newCols := make([]*Column, 0, len(f.Cols)+len(other.Cols))
+	newNameColMap := make(map[string]int)
+	for _, col := range f.Cols {
+		src, indices := col, li
+		if how == RightJoin && sharedKeys[col.Name] {
+			src, indices = other.Cols[other.NameColMap[col.Name]], ri
+		}
+		vec, err := selop.Take(src.Vec, indices)
+		if err != nil {
+			return nil, err
+		}
+		newNameColMap[col.Name] = len(newCols)
+		newCols = append(newCols, &Column{Name: col.Name, DType: src.DType, Vec: vec})
+	}
+	if how == SemiJoin || how == AntiJoin {
+		return &Frame{Cols: newCols, NameColMap: newNameColMap}, nil
+	}
+
+	for _, col := range other.Cols {
+		if sharedKeys[col.Name] {
+			continue
+		}
+		name := col.Name
+		if _, ok := newNameColMap[name]; ok {
+			name += suffix
+		}
+		if _, ok := newNameColMap[name]; ok {
+			return nil, fmt.Errorf("duplicate column name '%s' after suffixing", name)
+		}
+		vec, err := selop.Take(col.Vec, ri)
+		if err != nil {
+			return nil, err
+		}
+		newNameColMap[name] = len(newCols)
+		newCols = append(newCols, &Column{Name: name, DType: col.DType, Vec: vec})
+	}
+	return &Frame{Cols: newCols, NameColMap: newNameColMap}, nil
+}
+
+// joinKeys type-checks and evaluates the key expressions of a join, promoting numeric key pairs to a common type
+func joinKeys(left, right *Frame, leftOn, rightOn []ColExpr) ([]vector.Vector, []vector.Vector, error) {
+	lKeys := make([]vector.Vector, len(leftOn))
+	rKeys := make([]vector.Vector, len(rightOn))
+	for i := range leftOn {
+		lt, err := left.exprType(leftOn[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		rt, err := right.exprType(rightOn[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		t := lt
+		if dtype.IsNumeric(lt.Type()) && dtype.IsNumeric(rt.Type()) {
+			if t, err = numop.PromoteTypes(lt, rt); err != nil {
+				return nil, nil, err
+			}
+		} else if lt.Type() != rt.Type() {
+			return nil, nil, fmt.Errorf("mismatched join key types %v (%s) and %v (%s)", lt, leftOn[i], rt, rightOn[i])
+		}
+
+		if lKeys[i], err = left.eval(leftOn[i]); err != nil {
+			return nil, nil, err
+		}
+		if rKeys[i], err = right.eval(rightOn[i]); err != nil {
+			return nil, nil, err
+		}
+		if dtype.IsNumeric(t.Type()) {
+			if lKeys[i], err = numop.Promote(lKeys[i], t); err != nil {
+				return nil, nil, err
+			}
+			if rKeys[i], err = numop.Promote(rKeys[i], t); err != nil {
+				return nil, nil, err
+			}
+		}
+	}
+	return lKeys, rKeys, nil
+}
+
+// joinIndices returns the left and right row indices of each output row of a join; a -1 index marks a
+// row missing from that side. The right indices are nil for semi and anti joins.
+func joinIndices(lKeys, rKeys []vector.Vector, how JoinType) ([]int, []int, error) {
+	if how == RightJoin {
+		ri, li, err := joinIndices(rKeys, lKeys, LeftJoin)
+		return li, ri, err
+	}
+
+	nLeft, nRight := lKeys[0].Len(), rKeys[0].Len()
+	// rightsOf returns the right rows matching left row l, in order
+	var rightsOf func(l int) []int
+	// rightMatched returns whether right row r matches any left row
+	var rightMatched func(r int) bool
+
+	if nRight <= nLeft {
+		ids, nGroups, probeIDs, err := hashop.Matches(rKeys, lKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+		groups := compute.NewGroups(ids, nGroups)
+		rightsOf = func(l int) []int {
+			if probeIDs[l] == -1 {
+				return nil
+			}
+			return groups.GroupRows(probeIDs[l])
+		}
+		hit := make([]bool, nGroups)
+		for _, g := range probeIDs {
+			if g != -1 {
+				hit[g] = true
+			}
+		}
+		rightMatched = func(r int) bool { return hit[ids[r]] }
+	} else {
+		ids, nGroups, probeIDs, err := hashop.Matches(lKeys, rKeys)
+		if err != nil {
+			return nil, nil, err
+		}
+		// group the right rows by their matching left group; unmatched rows go in a final group
+		byGroup := make([]int, nRight)
+		for r, g := range probeIDs {
+			if g == -1 {
+				g = nGroups
+			}
+			byGroup[r] = g
+		}
+		groups := compute.NewGroups(byGroup, nGroups+1)
+		rightsOf = func(l int) []int { return groups.GroupRows(ids[l]) }
+		rightMatched = func(r int) bool { return probeIDs[r] != -1 }
+	}
+
+	// number of output rows of left row l
+	outRows := func(l int) int {
+		k := len(rightsOf(l))
+		switch how {
+		case InnerJoin:
+			return k
+		case SemiJoin:
+			return min(k, 1)
+		case AntiJoin:
+			return 1 - min(k, 1)
+		}
+		return max(k, 1)
+	}
+
+	// first pass: count the output rows of each chunk of left rows
+	chunkStart := make([]int, compute.NumWorkers)
+	compute.ParallelChunks(nLeft, 1, func(w, start, end int) {
+		var n int
+		for l := start; l < end; l++ {
+			n += outRows(l)
+		}
+		chunkStart[w] = n
+	})
+	var total int
+	for w, n := range chunkStart {
+		chunkStart[w] = total
+		total += n
+	}
+
+	var unmatched []int
+	if how == OuterJoin {
+		for r := 0; r < nRight; r++ {
+			if !rightMatched(r) {
+				unmatched = append(unmatched, r)
+			}
+		}
+	}
+
+	li := make([]int, total+len(unmatched))
+	var ri []int
+	if how != SemiJoin && how != AntiJoin {
+		ri = make([]int, total+len(unmatched))
+	}
+
+	// second pass: fill
+	compute.ParallelChunks(nLeft, 1, func(w, start, end int) {
+		j := chunkStart[w]
+		for l := start; l < end; l++ {
+			rights := rightsOf(l)
+			switch {
+			case how == SemiJoin || how == AntiJoin:
+				if (len(rights) > 0) == (how == SemiJoin) {
+					li[j] = l
+					j++
+				}
+			case len(rights) == 0:
+				if how != InnerJoin {
+					li[j], ri[j] = l, -1
+					j++
+				}
+			default:
+				for _, r := range rights {
+					li[j], ri[j] = l, r
+					j++
+				}
+			}
+		}
+	})
+
+	for k, r := range unmatched {
+		li[total+k], ri[total+k] = -1, r
+	}
+	return li, ri, nil
+}
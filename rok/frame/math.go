@@ -0,0 +1,370 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Math expressions follow numop on domain edges: out-of-domain elements become NaN, e.g. Sqrt of a
+// negative element, and poles become infinite, e.g. Log of 0.
+
+// mathOp represents a math function
+type mathOp int
+
+const (
+	mathAbs mathOp = iota
+	mathSign
+	mathRound
+	mathFloor
+	mathCeil
+	mathClip
+	mathSqrt
+	mathCbrt
+	mathExp
+	mathLog
+	mathLog10
+	mathLog2
+	mathSin
+	mathCos
+	mathTan
+	mathAsin
+	mathAcos
+	mathAtan
+	mathSinh
+	mathCosh
+	mathTanh
+	mathPow
+	mathHypot
+)
+
+var mathOpNames = map[mathOp]string{
+	mathAbs:   "abs",
+	mathSign:  "sign",
+	mathRound: "round",
+	mathFloor: "floor",
+	mathCeil:  "ceil",
+	mathClip:  "clip",
+	mathSqrt:  "sqrt",
+	mathCbrt:  "cbrt",
+	mathExp:   "exp",
+	mathLog:   "log",
+	mathLog10: "log10",
+	mathLog2:  "log2",
+	mathSin:   "sin",
+	mathCos:   "cos",
+	mathTan:   "tan",
+	mathAsin:  "asin",
+	mathAcos:  "acos",
+	mathAtan:  "atan",
+	mathSinh:  "sinh",
+	mathCosh:  "cosh",
+	mathTanh:  "tanh",
+	mathPow:   "pow",
+	mathHypot: "hypot",
+}
+
+// Abs returns an expression evaluating the absolute value of each element of c; c must be numeric
+func (c ColExpr) Abs() ColExpr {
+	return c.call(mathFunc(mathAbs, nil, false))
+}
+
+// Sign returns an expression evaluating the sign of each element of c, as -1, 0 or 1; c must be numeric
+func (c ColExpr) Sign() ColExpr {
+	return c.call(mathFunc(mathSign, nil, false))
+}
+
+// Round returns an expression rounding each element of c to the given number of decimal places, with
+// halves rounded away from zero; negative decimals round to the left of the decimal point. c must be numeric
+func (c ColExpr) Round(decimals int) ColExpr {
+	return c.call(mathFunc(mathRound, []any{decimals}, false))
+}
+
+// Floor returns an expression rounding each element of c towards negative infinity; c must be numeric
+func (c ColExpr) Floor() ColExpr {
+	return c.call(mathFunc(mathFloor, nil, false))
+}
+
+// Ceil returns an expression rounding each element of c towards positive infinity; c must be numeric
+func (c ColExpr) Ceil() ColExpr {
+	return c.call(mathFunc(mathCeil, nil, false))
+}
+
+// Clip returns an expression limiting each element of c to the range [lo, hi]; c must be numeric, and lo and hi
+// numeric literals representable as c's type
+func (c ColExpr) Clip(lo, hi any) ColExpr {
+	fn := mathFunc(mathClip, []any{lo, hi}, false)
+	outType := fn.outType
+	fn.outType = func(in []dtype.DataType) (dtype.DataType, error) {
+		t, err := outType(in)
+		if err != nil {
+			return nil, err
+		}
+		for _, lit := range []any{lo, hi} {
+			lt, err := litTypeAs(lit, t)
+			if err != nil {
+				return nil, err
+			}
+			if lt.Type() != t.Type() {
+				return nil, fmt.Errorf("clip: bound %v is not representable as %v", lit, t)
+			}
+		}
+		return t, nil
+	}
+	return c.call(fn)
+}
+
+// Sqrt returns an expression evaluating the square root of each element of c, as a float64; c must be numeric
+func (c ColExpr) Sqrt() ColExpr {
+	return c.call(mathFunc(mathSqrt, nil, true))
+}
+
+// Cbrt returns an expression evaluating the cube root of each element of c, as a float64; c must be numeric
+func (c ColExpr) Cbrt() ColExpr {
+	return c.call(mathFunc(mathCbrt, nil, true))
+}
+
+// Exp returns an expression evaluating the base-e exponential of each element of c, as a float64; c must be numeric
+func (c ColExpr) Exp() ColExpr {
+	return c.call(mathFunc(mathExp, nil, true))
+}
+
+// Log returns an expression evaluating the natural logarithm of each element of c, as a float64; c must be numeric
+func (c ColExpr) Log() ColExpr {
+	return c.call(mathFunc(mathLog, nil, true))
+}
+
+// Log10 returns an expression evaluating the base-10 logarithm of each element of c, as a float64; c must be numeric
+func (c ColExpr) Log10() ColExpr {
+	return c.call(mathFunc(mathLog10, nil, true))
+}
+
+// Log2 returns an expression evaluating the base-2 logarithm of each element of c, as a float64; c must be numeric
+func (c ColExpr) Log2() ColExpr {
+	return c.call(mathFunc(mathLog2, nil, true))
+}
+
+// Sin returns an expression evaluating the sine of each element of c, in radians, as a float64; c must be numeric
+func (c ColExpr) Sin() ColExpr {
+	return c.call(mathFunc(mathSin, nil, true))
+}
+
+// Cos returns an expression evaluating the cosine of each element of c, in radians, as a float64; c must be numeric
+func (c ColExpr) Cos() ColExpr {
+	return c.call(mathFunc(mathCos, nil, true))
+}
+
+// Tan returns an expression evaluating the tangent of each element of c, in radians, as a float64; c must be numeric
+func (c ColExpr) Tan() ColExpr {
+	return c.call(mathFunc(mathTan, nil, true))
+}
+
+// Asin returns an expression evaluating the arcsine of each element of c, in radians, as a float64; c must be numeric
+func (c ColExpr) Asin() ColExpr {
+	return c.call(mathFunc(mathAsin, nil, true))
+}
+
+// Acos returns an expression evaluating the arccosine of each element of c, in radians, as a float64; c must be numeric
+func (c ColExpr) Acos() ColExpr {
+	return c.call(mathFunc(mathAcos, nil, true))
+}
+
+// Atan returns an expression evaluating the arctangent of each element of c, in radians, as a float64; c must be numeric
+func (c ColExpr) Atan() ColExpr {
+	return c.call(mathFunc(mathAtan, nil, true))
+}
+
+// Sinh returns an expression evaluating the hyperbolic sine of each element of c, as a float64; c must be numeric
+func (c ColExpr) Sinh() ColExpr {
+	return c.call(mathFunc(mathSinh, nil, true))
+}
+
+// Cosh returns an expression evaluating the hyperbolic cosine of each element of c, as a float64; c must be numeric
+func (c ColExpr) Cosh() ColExpr {
+	return c.call(mathFunc(mathCosh, nil, true))
+}
+
+// Tanh returns an expression evaluating the hyperbolic tangent of each element of c, as a float64; c must be numeric
+func (c ColExpr) Tanh() ColExpr {
+	return c.call(mathFunc(mathTanh, nil, true))
+}
+
+// Pow returns an expression raising each element of c to the power of x, a numeric literal or expression;
+// both operands are promoted to a common type (see numop.PromoteTypes). Integer powers wrap on overflow, as
+// with Mul, and negative integer exponents evaluate to null.
+func (c ColExpr) Pow(x any) ColExpr {
+	return c.mathBinary(mathPow, x)
+}
+
+// Hypot returns an expression evaluating the Euclidean norm sqrt(c*c + x*x) of each element of c and x, a numeric
+// literal or expression, as a float64
+func (c ColExpr) Hypot(x any) ColExpr {
+	return c.mathBinary(mathHypot, x)
+}
+
+// mathBinary returns a function call node on c and x; a literal x is kept out of the arguments, so that it can be
+// typed as c
+func (c ColExpr) mathBinary(op mathOp, x any) ColExpr {
+	name := mathOpNames[op]
+	var params, extra []any
+	if e, ok := x.(ColExpr); ok {
+		extra = append(extra, e)
+	} else {
+		params = append(params, x)
+	}
+	operandTypes := func(in []dtype.DataType) (dtype.DataType, dtype.DataType, error) {
+		if len(in) > 1 {
+			return in[0], in[1], nil
+		}
+		t, err := litTypeAs(x, in[0])
+		return in[0], t, err
+	}
+
+	return c.call(&exprFunc{
+		name:   name,
+		params: params,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			xt, yt, err := operandTypes(in)
+			if err != nil {
+				return nil, err
+			}
+			t, err := numop.PromoteTypes(xt, yt)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if op == mathHypot {
+				return dtype.Float64{}, nil
+			}
+			return t, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			y := in[len(in)-1]
+			if len(in) == 1 {
+				var err error
+				if y, err = broadcastLitAs(x, in[0].Type(), in[0].Len()); err != nil {
+					return nil, err
+				}
+			}
+			switch op {
+			case mathPow:
+				return numop.Pow(in[0], y)
+			case mathHypot:
+				return hypot(in[0], y)
+			}
+			return nil, fmt.Errorf("invalid math function %s", name)
+		},
+	}, extra...)
+}
+
+// hypot evaluates the Euclidean norm of two numeric vectors, which is computed as float64 anyway
+func hypot(x, y vector.Vector) (vector.Vector, error) {
+	xf, err := numop.Promote(x, dtype.Float64{})
+	if err != nil {
+		return nil, err
+	}
+	yf, err := numop.Promote(y, dtype.Float64{})
+	if err != nil {
+		return nil, err
+	}
+	return numop.Hypot(xf.(*vector.NumericVector[float64]), yf.(*vector.NumericVector[float64])), nil
+}
+
+// mathFunc returns a function call node on one numeric argument; its output is a float64 if floatOut is set,
+// or else of the argument's type
+func mathFunc(op mathOp, params []any, floatOut bool) *exprFunc {
+	name := mathOpNames[op]
+	return &exprFunc{
+		name:   name,
+		params: params,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			if !dtype.IsNumeric(in[0].Type()) {
+				return nil, fmt.Errorf("%s: operand must be numeric, got %v", name, in[0])
+			}
+			if floatOut {
+				return dtype.Float64{}, nil
+			}
+			return in[0], nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			return mathNumeric(op, in[0], params)
+		},
+	}
+}
+
+func mathNumeric(op mathOp, v vector.Vector, params []any) (vector.Vector, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[uint16]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[uint32]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[uint64]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[int8]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[int16]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[int32]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[int64]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[int]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[float32]:
+		return mathAs(op, x, params)
+	case *vector.NumericVector[float64]:
+		return mathAs(op, x, params)
+	}
+	return nil, fmt.Errorf("unsupported operand %v for %s", v.Type(), mathOpNames[op])
+}
+
+func mathAs[T vector.Numeric](op mathOp, x *vector.NumericVector[T], params []any) (vector.Vector, error) {
+	switch op {
+	case mathAbs:
+		return numop.Abs(x), nil
+	case mathSign:
+		return numop.Sign(x), nil
+	case mathRound:
+		return numop.Round(x, params[0].(int)), nil
+	case mathFloor:
+		return numop.Floor(x), nil
+	case mathCeil:
+		return numop.Ceil(x), nil
+	case mathClip:
+		return numop.Clip(x, numericLitAs[T](params[0]), numericLitAs[T](params[1])), nil
+	case mathSqrt:
+		return numop.Sqrt(x), nil
+	case mathCbrt:
+		return numop.Cbrt(x), nil
+	case mathExp:
+		return numop.Exp(x), nil
+	case mathLog:
+		return numop.Log(x), nil
+	case mathLog10:
+		return numop.Log10(x), nil
+	case mathLog2:
+		return numop.Log2(x), nil
+	case mathSin:
+		return numop.Sin(x), nil
+	case mathCos:
+		return numop.Cos(x), nil
+	case mathTan:
+		return numop.Tan(x), nil
+	case mathAsin:
+		return numop.Asin(x), nil
+	case mathAcos:
+		return numop.Acos(x), nil
+	case mathAtan:
+		return numop.Atan(x), nil
+	case mathSinh:
+		return numop.Sinh(x), nil
+	case mathCosh:
+		return numop.Cosh(x), nil
+	case mathTanh:
+		return numop.Tanh(x), nil
+	}
+	return nil, fmt.Errorf("invalid math function %s", mathOpNames[op])
+}
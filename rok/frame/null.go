@@ -0,0 +1,185 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/nullop"
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// IsNull returns an expression evaluating whether each element of c is null
+func (c ColExpr) IsNull() ColExpr {
+	return c.call(nullFunc("is_null", nullop.IsNull))
+}
+
+// IsNotNull returns an expression evaluating whether each element of c is not null
+func (c ColExpr) IsNotNull() ColExpr {
+	return c.call(nullFunc("is_not_null", nullop.IsNotNull))
+}
+
+// FillNull returns an expression replacing the null elements of c with x, a literal or expression
+// of c's type; numeric types are promoted to a common type (see Coalesce)
+func (c ColExpr) FillNull(x any) ColExpr {
+	return Coalesce(c, x).Alias(c.Name)
+}
+
+// FillNullForward returns an expression replacing each null element of c with the closest non-null
+// element before it; leading null elements stay null
+func (c ColExpr) FillNullForward() ColExpr {
+	return c.call(fillFunc("fill_null_forward", nullop.FillNullForward))
+}
+
+// FillNullBackward returns an expression replacing each null element of c with the closest non-null
+// element after it; trailing null elements stay null
+func (c ColExpr) FillNullBackward() ColExpr {
+	return c.call(fillFunc("fill_null_backward", nullop.FillNullBackward))
+}
+
+// Coalesce returns an expression evaluating the first non-null element of its arguments at each row.
+//
+// The first argument must be an expression; the rest may be expressions of the same type, or literals,
+// which are typed as the expressions. Numeric arguments of differing types are promoted to a common type
+// (see numop.PromoteTypes).
+func Coalesce(x ...any) ColExpr {
+	// literals are kept out of the arguments, so that they can be typed as the first argument
+	var args []ColExpr
+	var lits []any
+	slots := make([]any, len(x)) // argument index, or literal
+	for i, v := range x {
+		if e, ok := v.(ColExpr); ok {
+			slots[i] = len(args)
+			args = append(args, e)
+		} else {
+			slots[i] = litSlot{v}
+			lits = append(lits, v)
+		}
+	}
+
+	// common type of the expressions, then of the literals, typed as the expressions
+	argType := func(in []dtype.DataType) (dtype.DataType, error) {
+		if len(x) == 0 || len(in) == 0 || slots[0] != 0 {
+			return nil, fmt.Errorf("coalesce: first argument must be an expression")
+		}
+		t := in[0]
+		var err error
+		for _, st := range in[1:] {
+			if t, err = unifyTypes(t, st); err != nil {
+				return nil, fmt.Errorf("coalesce: %w", err)
+			}
+		}
+		for _, lit := range lits {
+			lt, err := litTypeAs(lit, t)
+			if err != nil {
+				return nil, fmt.Errorf("coalesce: %w", err)
+			}
+			if t, err = unifyTypes(t, lt); err != nil {
+				return nil, fmt.Errorf("coalesce: %w", err)
+			}
+		}
+		return t, nil
+	}
+
+	fn := &exprFunc{
+		name:    "coalesce",
+		params:  lits,
+		outType: argType,
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			types := make([]dtype.DataType, len(in))
+			for i, v := range in {
+				types[i] = v.Type()
+			}
+			t, err := argType(types)
+			if err != nil {
+				return nil, err
+			}
+			vecs := make([]vector.Vector, len(slots))
+			for i, s := range slots {
+				switch s := s.(type) {
+				case int:
+					vecs[i] = in[s]
+					if dtype.IsNumeric(t.Type()) {
+						if vecs[i], err = numop.Promote(vecs[i], t); err != nil {
+							return nil, err
+						}
+					}
+				case litSlot:
+					if vecs[i], err = broadcastLit(s.lit, t, in[0].Len()); err != nil {
+						return nil, err
+					}
+				}
+			}
+			return nullop.Coalesce(vecs...)
+		},
+	}
+
+	name := "coalesce"
+	if len(args) > 0 {
+		name = args[0].Name
+	}
+	return ColExpr{Name: name, kind: funcExpr, fn: fn, args: args}
+}
+
+// litSlot holds a literal argument of Coalesce
+type litSlot struct {
+	lit any
+}
+
+// DropNulls returns a new Frame, dropping the rows with a null element in any of the columns in subset,
+// or in any column if subset is empty
+func (f *Frame) DropNulls(subset ...string) (*Frame, error) {
+	cols := f.Cols
+	if len(subset) > 0 {
+		cols = make([]*Column, len(subset))
+		for i, name := range subset {
+			idx, ok := f.NameColMap[name]
+			if !ok {
+				return nil, fmt.Errorf("Column '%s' not recognized", name)
+			}
+			cols[i] = f.Cols[idx]
+		}
+	}
+
+	n := f.Height()
+	valid := vector.NewValidityBitMap(n)
+	maskBuff := valid.DeepCopyBuff()
+	for _, col := range cols {
+		if col.Vec.NullCount() == 0 {
+			continue
+		}
+		colValid := col.Vec.Validity().Buffer
+		for b := range maskBuff {
+			maskBuff[b] &= colValid[b]
+		}
+	}
+	mask := vector.BoolVecFromComponenets(dtype.Bool{}, maskBuff, valid)
+	return f.take(selop.MaskIndices(mask))
+}
+
+// nullFunc returns a function call node on one argument of any type, whose output is a bool mask
+func nullFunc(name string, fn func(v vector.Vector) *vector.BoolVector) *exprFunc {
+	return &exprFunc{
+		name: name,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			return dtype.Bool{}, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			return fn(in[0]), nil
+		},
+	}
+}
+
+// fillFunc returns a function call node on one argument of any type, whose output is of the same type
+func fillFunc(name string, fn func(v vector.Vector) (vector.Vector, error)) *exprFunc {
+	return &exprFunc{
+		name: name,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			return in[0], nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			return fn(in[0])
+		},
+	}
+}
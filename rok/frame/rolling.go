@@ -0,0 +1,190 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/winop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Rolling configures the windows of a rolling aggregation (see winop.RollingOptions)
+type Rolling struct {
+	Window     int    // number of rows in each window, or length of time-based windows, in units of By
+	By         string // if set, a sorted date or integer column keying time-based windows, e.g. Window: 7 over a date column is 7 days
+	MinPeriods int    // minimum number of non-null elements for a non-null result; defaults to Window, or 1 for time-based windows
+	Center     bool   // center each window on its row; fixed-count windows only, an error with By
+}
+
+// rollOp represents a rolling aggregation
+type rollOp int
+
+const (
+	rollSum rollOp = iota
+	rollMean
+	rollMin
+	rollMax
+	rollStd
+)
+
+var rollOpNames = map[rollOp]string{
+	rollSum:  "rolling_sum",
+	rollMean: "rolling_mean",
+	rollMin:  "rolling_min",
+	rollMax:  "rolling_max",
+	rollStd:  "rolling_std",
+}
+
+// RollingSum returns an expression evaluating the sum of c's non-null elements over a rolling window; c must be numeric
+func (c ColExpr) RollingSum(r Rolling) ColExpr {
+	return c.rolling(rollSum, r, 0)
+}
+
+// RollingMean returns an expression evaluating the mean of c's non-null elements over a rolling window, as a float64;
+// c must be numeric
+func (c ColExpr) RollingMean(r Rolling) ColExpr {
+	return c.rolling(rollMean, r, 0)
+}
+
+// RollingMin returns an expression evaluating the minimum of c's non-null elements over a rolling window; c must be numeric
+func (c ColExpr) RollingMin(r Rolling) ColExpr {
+	return c.rolling(rollMin, r, 0)
+}
+
+// RollingMax returns an expression evaluating the maximum of c's non-null elements over a rolling window; c must be numeric
+func (c ColExpr) RollingMax(r Rolling) ColExpr {
+	return c.rolling(rollMax, r, 0)
+}
+
+// RollingStd returns an expression evaluating the standard deviation of c's non-null elements over a rolling window,
+// with n - ddof degrees of freedom, as a float64; c must be numeric
+func (c ColExpr) RollingStd(r Rolling, ddof int) ColExpr {
+	return c.rolling(rollStd, r, ddof)
+}
+
+func (c ColExpr) rolling(op rollOp, r Rolling, ddof int) ColExpr {
+	name := rollOpNames[op]
+	var extra []any
+	params := []any{r.Window}
+	if r.By != "" {
+		extra = append(extra, Col(r.By))
+	}
+	if op == rollStd {
+		params = append(params, ddof)
+	}
+	return c.call(&exprFunc{
+		name:   name,
+		params: params,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			if !dtype.IsNumeric(in[0].Type()) {
+				return nil, fmt.Errorf("%s: operand must be numeric, got %v", name, in[0])
+			}
+			if len(in) > 1 && in[1].Type() != dtype.DATE && !dtype.IsInteger(in[1].Type()) {
+				return nil, fmt.Errorf("%s: window key must be a date or integer, got %v", name, in[1])
+			}
+			if len(in) > 1 && r.Center {
+				return nil, fmt.Errorf("%s: time-based windows, by %s, cannot be centered", name, r.By)
+			}
+			if op == rollMean || op == rollStd {
+				return dtype.Float64{}, nil
+			}
+			return in[0], nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			opts := winop.RollingOptions{Window: r.Window, MinPeriods: r.MinPeriods, Center: r.Center}
+			if len(in) > 1 {
+				times, err := rollingTimes(in[1])
+				if err != nil {
+					return nil, err
+				}
+				opts.Times = times
+			}
+			return rollNumeric(op, in[0], opts, ddof)
+		},
+	}, extra...)
+}
+
+// rollingTimes returns the non-null keys of time-based windows as int64s
+func rollingTimes(v vector.Vector) ([]int64, error) {
+	if v.NullCount() > 0 {
+		return nil, fmt.Errorf("rolling window key must not contain nulls")
+	}
+	times := make([]int64, v.Len())
+	switch x := v.(type) {
+	case *vector.DateVector:
+		for i, d := range x.Data() {
+			times[i] = int64(d)
+		}
+	case *vector.NumericVector[uint8]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[uint16]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[uint32]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[uint64]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[int8]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[int16]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[int32]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[int64]:
+		widenInt64(times, x.Data())
+	case *vector.NumericVector[int]:
+		widenInt64(times, x.Data())
+	default:
+		return nil, fmt.Errorf("unsupported rolling window key %v", v.Type())
+	}
+	return times, nil
+}
+
+func widenInt64[T vector.Numeric](dst []int64, src []T) {
+	for i, x := range src {
+		dst[i] = int64(x)
+	}
+}
+
+func rollNumeric(op rollOp, v vector.Vector, opts winop.RollingOptions, ddof int) (vector.Vector, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[uint16]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[uint32]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[uint64]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[int8]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[int16]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[int32]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[int64]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[int]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[float32]:
+		return rollAs(op, x, opts, ddof)
+	case *vector.NumericVector[float64]:
+		return rollAs(op, x, opts, ddof)
+	}
+	return nil, fmt.Errorf("unsupported operand %v for %s", v.Type(), rollOpNames[op])
+}
+
+func rollAs[T vector.Numeric](op rollOp, x *vector.NumericVector[T], opts winop.RollingOptions, ddof int) (vector.Vector, error) {
+	switch op {
+	case rollSum:
+		return winop.RollingSum(x, opts)
+	case rollMean:
+		return winop.RollingMean(x, opts)
+	case rollMin:
+		return winop.RollingMin(x, opts)
+	case rollMax:
+		return winop.RollingMax(x, opts)
+	case rollStd:
+		return winop.RollingStd(x, opts, ddof)
+	}
+	return nil, fmt.Errorf("invalid rolling aggregation %s", rollOpNames[op])
+}
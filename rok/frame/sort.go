@@ -0,0 +1,37 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/sortop"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// Sort returns a new Frame, with rows stably sorted by one or more key expressions (see sortop.ArgSortMulti).
+//
+// descending holds the sort direction of each key, or may be empty to sort all keys in ascending order;
+// null keys come first, or last if nullsLast is set.
+func (f *Frame) Sort(by []ColExpr, descending []bool, nullsLast bool) (*Frame, error) {
+	if len(by) == 0 {
+		return nil, fmt.Errorf("Sort requires at least one key")
+	}
+	for _, e := range by {
+		if _, err := f.exprType(e); err != nil {
+			return nil, err
+		}
+	}
+
+	keys := make([]vector.Vector, len(by))
+	for i, e := range by {
+		v, err := f.eval(e)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = v
+	}
+	indices, err := sortop.ArgSortMulti(keys, descending, nullsLast)
+	if err != nil {
+		return nil, err
+	}
+	return f.take(indices)
+}
@@ -0,0 +1,301 @@
+package frame
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/rhawrami/rok-frame/rok/compute/strop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// ToUpperASCII returns an expression converting a string expression to upper-case; assumes bytes are ASCII
+func (c ColExpr) ToUpperASCII() ColExpr {
+	return c.call(strFunc("to_upper_ascii", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.ToUpperASCII(x[0])
+	}))
+}
+
+// ToLowerASCII returns an expression converting a string expression to lower-case; assumes bytes are ASCII
+func (c ColExpr) ToLowerASCII() ColExpr {
+	return c.call(strFunc("to_lower_ascii", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.ToLowerASCII(x[0])
+	}))
+}
+
+// ToTitleASCII returns an expression converting a string expression to title-case; assumes bytes are ASCII
+func (c ColExpr) ToTitleASCII() ColExpr {
+	return c.call(strFunc("to_title_ascii", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.ToTitleASCII(x[0])
+	}))
+}
+
+// SwapCaseASCII returns an expression swapping the case of a string expression; assumes bytes are ASCII
+func (c ColExpr) SwapCaseASCII() ColExpr {
+	return c.call(strFunc("swap_case_ascii", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.SwapCaseASCII(x[0])
+	}))
+}
+
+// ToUpper returns an expression converting a string expression to upper-case, following Unicode
+func (c ColExpr) ToUpper() ColExpr {
+	return c.call(strFunc("to_upper", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.ToUpper(x[0])
+	}))
+}
+
+// ToLower returns an expression converting a string expression to lower-case, following Unicode
+func (c ColExpr) ToLower() ColExpr {
+	return c.call(strFunc("to_lower", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.ToLower(x[0])
+	}))
+}
+
+// ToTitle returns an expression converting the first letter of each word of a string expression to
+// title-case, and the rest to lower-case, following Unicode
+func (c ColExpr) ToTitle() ColExpr {
+	return c.call(strFunc("to_title", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.ToTitle(x[0])
+	}))
+}
+
+// CaseFold returns an expression folding the case of a string expression, for case-insensitive comparison
+func (c ColExpr) CaseFold() ColExpr {
+	return c.call(strFunc("case_fold", nil, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.CaseFold(x[0])
+	}))
+}
+
+// AddPrefix returns an expression adding a prefix to each element of a string expression
+func (c ColExpr) AddPrefix(s string) ColExpr {
+	return c.call(strFunc("add_prefix", []any{s}, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.AddPrefix(x[0], []byte(s))
+	}))
+}
+
+// AddSuffix returns an expression adding a suffix to each element of a string expression
+func (c ColExpr) AddSuffix(s string) ColExpr {
+	return c.call(strFunc("add_suffix", []any{s}, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.AddSuffix(x[0], []byte(s))
+	}))
+}
+
+// Concat returns an expression concatenating a string expression with x element-wise, joined by sep
+func (c ColExpr) Concat(x any, sep string) ColExpr {
+	return c.call(strFunc("concat", []any{sep}, func(x []*vector.StringVector) *vector.StringVector {
+		return strop.Concat(x[0], x[1], []byte(sep))
+	}), x)
+}
+
+// Contains returns an expression evaluating whether each element of a string expression contains sub
+func (c ColExpr) Contains(sub string) ColExpr {
+	return c.call(strQueryFunc("contains", []any{sub}, dtype.Bool{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.Contains(x, []byte(sub))
+	}))
+}
+
+// StartsWith returns an expression evaluating whether each element of a string expression begins with prefix
+func (c ColExpr) StartsWith(prefix string) ColExpr {
+	return c.call(strQueryFunc("starts_with", []any{prefix}, dtype.Bool{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.StartsWith(x, []byte(prefix))
+	}))
+}
+
+// EndsWith returns an expression evaluating whether each element of a string expression ends with suffix
+func (c ColExpr) EndsWith(suffix string) ColExpr {
+	return c.call(strQueryFunc("ends_with", []any{suffix}, dtype.Bool{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.EndsWith(x, []byte(suffix))
+	}))
+}
+
+// Find returns an expression evaluating the byte index of the first instance of sub in each element of a
+// string expression, as an int64; elements not containing sub are null
+func (c ColExpr) Find(sub string) ColExpr {
+	return c.call(strQueryFunc("find", []any{sub}, dtype.Int64{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.Find(x, []byte(sub))
+	}))
+}
+
+// MatchRegex returns an expression evaluating whether each element of a string expression contains a match
+// of the regular expression pattern, compiled once; an invalid pattern is reported when the expression is type-checked
+func (c ColExpr) MatchRegex(pattern string) ColExpr {
+	re, err := regexp.Compile(pattern)
+	return c.call(strQueryFunc("match_regex", []any{pattern}, dtype.Bool{}, err, func(x *vector.StringVector) vector.Vector {
+		return strop.MatchRegex(x, re)
+	}))
+}
+
+// CountMatches returns an expression evaluating the number of non-overlapping instances of sub in each
+// element of a string expression, as an int64
+func (c ColExpr) CountMatches(sub string) ColExpr {
+	return c.call(strQueryFunc("count_matches", []any{sub}, dtype.Int64{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.CountMatches(x, []byte(sub))
+	}))
+}
+
+// CountMatchesRegex returns an expression evaluating the number of non-overlapping matches of the regular
+// expression pattern in each element of a string expression, as an int64
+func (c ColExpr) CountMatchesRegex(pattern string) ColExpr {
+	re, err := regexp.Compile(pattern)
+	return c.call(strQueryFunc("count_matches_regex", []any{pattern}, dtype.Int64{}, err, func(x *vector.StringVector) vector.Vector {
+		return strop.CountMatchesRegex(x, re)
+	}))
+}
+
+// Replace returns an expression replacing the first instance of old with new, in each element of a string expression
+func (c ColExpr) Replace(old, new string) ColExpr {
+	return c.call(strQueryFunc("replace", []any{old, new}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.Replace(x, []byte(old), []byte(new))
+	}))
+}
+
+// ReplaceAll returns an expression replacing all instances of old with new, in each element of a string expression
+func (c ColExpr) ReplaceAll(old, new string) ColExpr {
+	return c.call(strQueryFunc("replace_all", []any{old, new}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.ReplaceAll(x, []byte(old), []byte(new))
+	}))
+}
+
+// ReplaceRegex returns an expression replacing the first match of the regular expression pattern with repl,
+// in each element of a string expression; repl may refer to submatches, e.g. "${1}"
+func (c ColExpr) ReplaceRegex(pattern, repl string) ColExpr {
+	re, err := regexp.Compile(pattern)
+	return c.call(strQueryFunc("replace_regex", []any{pattern, repl}, dtype.String{}, err, func(x *vector.StringVector) vector.Vector {
+		return strop.ReplaceRegex(x, re, []byte(repl))
+	}))
+}
+
+// ReplaceAllRegex returns an expression replacing all matches of the regular expression pattern with repl,
+// in each element of a string expression; repl may refer to submatches, e.g. "${1}"
+func (c ColExpr) ReplaceAllRegex(pattern, repl string) ColExpr {
+	re, err := regexp.Compile(pattern)
+	return c.call(strQueryFunc("replace_all_regex", []any{pattern, repl}, dtype.String{}, err, func(x *vector.StringVector) vector.Vector {
+		return strop.ReplaceAllRegex(x, re, []byte(repl))
+	}))
+}
+
+// Slice returns an expression evaluating the substring of up to length runes of each element of a string
+// expression, beginning at rune start (see strop.Slice)
+func (c ColExpr) Slice(start, length int) ColExpr {
+	return c.call(strQueryFunc("slice", []any{start, length}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.Slice(x, start, length)
+	}))
+}
+
+// SplitN returns n expressions splitting a string expression around instances of sep: the i-th evaluates
+// the i-th part of each element, named "<name>_<i>", and the last the unsplit remainder. Elements with
+// fewer than i+1 parts are null in the i-th.
+func (c ColExpr) SplitN(sep string, n int) []ColExpr {
+	parts := make([]ColExpr, n)
+	for i := range parts {
+		parts[i] = c.call(strQueryFunc("split_part", []any{sep, i, n}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+			return strop.SplitPart(x, []byte(sep), i, n)
+		})).Alias(fmt.Sprintf("%s_%d", c.Name, i))
+	}
+	return parts
+}
+
+// Trim returns an expression removing all leading and trailing runes contained in cutset from each element
+// of a string expression; an empty cutset removes white space
+func (c ColExpr) Trim(cutset string) ColExpr {
+	return c.call(strQueryFunc("trim", []any{cutset}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.Trim(x, cutset)
+	}))
+}
+
+// TrimLeft returns an expression removing all leading runes contained in cutset from each element of a
+// string expression; an empty cutset removes white space
+func (c ColExpr) TrimLeft(cutset string) ColExpr {
+	return c.call(strQueryFunc("trim_left", []any{cutset}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.TrimLeft(x, cutset)
+	}))
+}
+
+// TrimRight returns an expression removing all trailing runes contained in cutset from each element of a
+// string expression; an empty cutset removes white space
+func (c ColExpr) TrimRight(cutset string) ColExpr {
+	return c.call(strQueryFunc("trim_right", []any{cutset}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.TrimRight(x, cutset)
+	}))
+}
+
+// PadLeft returns an expression padding the start of each element of a string expression with fill, to a
+// length of width runes
+func (c ColExpr) PadLeft(width int, fill rune) ColExpr {
+	return c.call(strQueryFunc("pad_left", []any{width, string(fill)}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.PadLeft(x, width, fill)
+	}))
+}
+
+// PadRight returns an expression padding the end of each element of a string expression with fill, to a
+// length of width runes
+func (c ColExpr) PadRight(width int, fill rune) ColExpr {
+	return c.call(strQueryFunc("pad_right", []any{width, string(fill)}, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.PadRight(x, width, fill)
+	}))
+}
+
+// Reverse returns an expression reversing the runes of each element of a string expression
+func (c ColExpr) Reverse() ColExpr {
+	return c.call(strQueryFunc("reverse", nil, dtype.String{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.Reverse(x)
+	}))
+}
+
+// LenBytes returns an expression evaluating the length in bytes of each element of a string expression, as an int32
+func (c ColExpr) LenBytes() ColExpr {
+	return c.call(strQueryFunc("len_bytes", nil, dtype.Int32{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.LenBytes(x)
+	}))
+}
+
+// LenChars returns an expression evaluating the length in runes of each element of a string expression, as an int32
+func (c ColExpr) LenChars() ColExpr {
+	return c.call(strQueryFunc("len_chars", nil, dtype.Int32{}, nil, func(x *vector.StringVector) vector.Vector {
+		return strop.LenChars(x)
+	}))
+}
+
+// strQueryFunc returns a function call node on one string argument, whose output is of type out;
+// a non-nil err, such as from compiling a pattern, fails type-checking
+func strQueryFunc(name string, params []any, out dtype.DataType, err error, fn func(x *vector.StringVector) vector.Vector) *exprFunc {
+	return &exprFunc{
+		name:   name,
+		params: params,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", name, err)
+			}
+			if in[0].Type() != dtype.STRING {
+				return nil, fmt.Errorf("%s: operand must be string, got %v", name, in[0])
+			}
+			return out, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			return fn(in[0].(*vector.StringVector)), nil
+		},
+	}
+}
+
+// strFunc returns a function call node whose arguments and output are all strings
+func strFunc(name string, params []any, fn func(x []*vector.StringVector) *vector.StringVector) *exprFunc {
+	return &exprFunc{
+		name:   name,
+		params: params,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			for _, t := range in {
+				if t.Type() != dtype.STRING {
+					return nil, fmt.Errorf("arguments must be string, got %v", t)
+				}
+			}
+			return dtype.String{}, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			args := make([]*vector.StringVector, len(in))
+			for i, v := range in {
+				args[i] = v.(*vector.StringVector)
+			}
+			return fn(args), nil
+		},
+	}
+}
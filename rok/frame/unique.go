@@ -0,0 +1,79 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/hashop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// IsDuplicated returns an expression evaluating whether each element of c occurs more than once; every
+// occurrence of a duplicated element is true, including the first
+func (c ColExpr) IsDuplicated() ColExpr {
+	return c.call(&exprFunc{
+		name: "is_duplicated",
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			return dtype.Bool{}, nil
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			return hashop.IsDuplicated(in[:1])
+		},
+	})
+}
+
+// Distinct returns a new Frame, keeping the first row of each distinct combination of the columns in subset,
+// or of all columns if subset is empty; rows stay in order. Nulls are equal to each other, as in GroupBy.
+func (f *Frame) Distinct(subset ...string) (*Frame, error) {
+	cols := f.Cols
+	if len(subset) > 0 {
+		cols = make([]*Column, len(subset))
+		for i, name := range subset {
+			idx, ok := f.NameColMap[name]
+			if !ok {
+				return nil, fmt.Errorf("Column '%s' not recognized", name)
+			}
+			cols[i] = f.Cols[idx]
+		}
+	}
+	if len(cols) == 0 {
+		return f.take(nil)
+	}
+
+	keys := make([]vector.Vector, len(cols))
+	for i, col := range cols {
+		keys[i] = col.Vec
+	}
+	_, firstRows, err := hashop.GroupIDs(keys)
+	if err != nil {
+		return nil, err
+	}
+	return f.take(firstRows)
+}
+
+// ValueCounts returns a new Frame with the distinct elements of an expression, and a "count" column holding
+// the number of occurrences of each, sorted by descending count (see hashop.ValueCounts)
+func (f *Frame) ValueCounts(e ColExpr) (*Frame, error) {
+	t, err := f.exprType(e)
+	if err != nil {
+		return nil, err
+	}
+	if e.Name == "count" {
+		return nil, fmt.Errorf("duplicate column name 'count'; use Alias to rename")
+	}
+	v, err := f.eval(e)
+	if err != nil {
+		return nil, err
+	}
+	values, counts, err := hashop.ValueCounts(v)
+	if err != nil {
+		return nil, err
+	}
+	return &Frame{
+		Cols: []*Column{
+			{Name: e.Name, DType: t, Vec: values},
+			{Name: "count", DType: dtype.Int64{}, Vec: counts},
+		},
+		NameColMap: map[string]int{e.Name: 0, "count": 1},
+	}, nil
+}
@@ -0,0 +1,202 @@
+package frame
+
+import (
+	"fmt"
+
+	"github.com/rhawrami/rok-frame/rok/compute/numop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// WhenExpr is a conditional expression awaiting the value of its latest condition; see When
+type WhenExpr struct {
+	chain whenChain
+	err   error
+}
+
+// whenChain holds the branches of a conditional expression; stored in the lit of its funcExpr node
+type whenChain struct {
+	conds        []ColExpr
+	values       []any // expressions or literals, one per condition
+	otherwise    any
+	hasOtherwise bool
+}
+
+// When starts a conditional expression, evaluating to the value of the first branch whose condition is
+// true at each row:
+//
+//	When(Col("x").Gt(0)).Then("pos").When(Col("x").Lt(0)).Then("neg").Otherwise("zero")
+//
+// Conditions must be bool expressions; null conditions are treated as false. Values may be expressions
+// of one type, or literals, which are typed as the value expressions; numeric values of differing types are
+// promoted to a common type (see numop.PromoteTypes). Rows matching no branch take the Otherwise value, or
+// are null.
+func When(cond ColExpr) WhenExpr {
+	return WhenExpr{chain: whenChain{conds: []ColExpr{cond}}}
+}
+
+// Then sets the value of the latest condition, returning the conditional expression
+func (w WhenExpr) Then(x any) ColExpr {
+	chain := w.chain
+	chain.values = append(chain.values[:len(chain.values):len(chain.values)], x)
+	return chain.expr(w.err)
+}
+
+// When adds a condition to c, which must be a conditional expression without an Otherwise value
+func (c ColExpr) When(cond ColExpr) WhenExpr {
+	chain, ok := c.lit.(whenChain)
+	if c.kind != funcExpr || !ok {
+		return WhenExpr{chain: whenChain{conds: []ColExpr{cond}}, err: fmt.Errorf("when: %s is not a conditional expression", c)}
+	}
+	var err error
+	if chain.hasOtherwise {
+		err = fmt.Errorf("condition added after otherwise")
+	}
+	chain.conds = append(chain.conds[:len(chain.conds):len(chain.conds)], cond)
+	return WhenExpr{chain: chain, err: err}
+}
+
+// Otherwise sets the value of c for rows matching none of its conditions; c must be a conditional expression
+func (c ColExpr) Otherwise(x any) ColExpr {
+	chain, ok := c.lit.(whenChain)
+	if c.kind != funcExpr || !ok {
+		return whenChain{}.expr(fmt.Errorf("otherwise: %s is not a conditional expression", c))
+	}
+	var err error
+	if chain.hasOtherwise {
+		err = fmt.Errorf("otherwise set more than once")
+	}
+	chain.otherwise, chain.hasOtherwise = x, true
+	return chain.expr(err)
+}
+
+// expr returns the function call node of the chain; its arguments are the conditions, followed by the
+// value expressions. Literal values are kept out of the arguments, so that they can be typed as the
+// value expressions. err, if set, is reported when the expression is type-checked.
+func (w whenChain) expr(err error) ColExpr {
+	args := append([]ColExpr{}, w.conds...)
+	values := w.values
+	if w.hasOtherwise {
+		values = append(values[:len(values):len(values)], w.otherwise)
+	}
+	var lits []any
+	slots := make([]any, len(values)) // argument index, or literal
+	for i, v := range values {
+		if e, ok := v.(ColExpr); ok {
+			slots[i] = len(args)
+			args = append(args, e)
+		} else {
+			slots[i] = litSlot{v}
+			lits = append(lits, v)
+		}
+	}
+
+	// the output type is the common type of the value expressions, then of the literals, typed as the
+	// value expressions; or as the first literal if there are none
+	valueType := func(in []dtype.DataType) (dtype.DataType, error) {
+		var t dtype.DataType
+		for _, s := range slots {
+			k, ok := s.(int)
+			if !ok {
+				continue
+			}
+			if t == nil {
+				t = in[k]
+				continue
+			}
+			var err error
+			if t, err = unifyTypes(t, in[k]); err != nil {
+				return nil, err
+			}
+		}
+		for _, lit := range lits {
+			if t == nil {
+				var err error
+				if t, err = litType(lit); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			lt, err := litTypeAs(lit, t)
+			if err != nil {
+				return nil, err
+			}
+			if t, err = unifyTypes(t, lt); err != nil {
+				return nil, err
+			}
+		}
+		return t, nil
+	}
+
+	fn := &exprFunc{
+		name:   "when",
+		params: lits,
+		outType: func(in []dtype.DataType) (dtype.DataType, error) {
+			if err != nil {
+				return nil, err
+			}
+			if len(w.values) != len(w.conds) || len(slots) == 0 {
+				return nil, fmt.Errorf("condition without a value")
+			}
+			for i := range w.conds {
+				if in[i].Type() != dtype.BOOL {
+					return nil, fmt.Errorf("condition must be bool, got %v", in[i])
+				}
+			}
+			return valueType(in)
+		},
+		eval: func(in []vector.Vector) (vector.Vector, error) {
+			inTypes := make([]dtype.DataType, len(in))
+			for i, v := range in {
+				inTypes[i] = v.Type()
+			}
+			t, err := valueType(inTypes)
+			if err != nil {
+				return nil, err
+			}
+
+			// literals are broadcast by IfElse, from vectors of length 1
+			vecs := make([]vector.Vector, len(slots))
+			for i, s := range slots {
+				switch s := s.(type) {
+				case int:
+					vecs[i] = in[s]
+					if dtype.IsNumeric(t.Type()) {
+						if vecs[i], err = numop.Promote(vecs[i], t); err != nil {
+							return nil, err
+						}
+					}
+				case litSlot:
+					if vecs[i], err = broadcastLit(s.lit, t, 1); err != nil {
+						return nil, err
+					}
+				}
+			}
+
+			// fold from the last branch, starting from the otherwise value, or nulls
+			var acc vector.Vector
+			if w.hasOtherwise {
+				acc = vecs[len(vecs)-1]
+			} else if acc, err = selop.Take(vecs[0], []int{-1}); err != nil {
+				return nil, err
+			}
+			for k := len(w.conds) - 1; k >= 0; k-- {
+				if acc, err = selop.IfElse(in[k].(*vector.BoolVector), vecs[k], acc); err != nil {
+					return nil, err
+				}
+			}
+			return acc, nil
+		},
+	}
+
+	// named after the first value expression, as with the other function calls
+	name := "literal"
+	for _, s := range slots {
+		if k, ok := s.(int); ok {
+			name = args[k].Name
+			break
+		}
+	}
+	return ColExpr{Name: name, kind: funcExpr, lit: w, fn: fn, args: args}
+}
@@ -0,0 +1,322 @@
+package frame
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rhawrami/rok-frame/rok/compute"
+	"github.com/rhawrami/rok-frame/rok/compute/hashop"
+	"github.com/rhawrami/rok-frame/rok/compute/selop"
+	"github.com/rhawrami/rok-frame/rok/compute/sortop"
+	"github.com/rhawrami/rok-frame/rok/compute/winop"
+	"github.com/rhawrami/rok-frame/rok/dtype"
+	"github.com/rhawrami/rok-frame/rok/vector"
+)
+
+// windowFn represents a window function
+type windowFn int
+
+const (
+	winCumSum windowFn = iota
+	winRowNumber
+	winRank
+	winDenseRank
+	winLag
+	winLead
+	winFirst
+	winLast
+	winAgg  // aggregation, broadcast to each row of its partition
+	winNone // Over or OrderBy applied to an expression that is not a window function
+)
+
+var windowFnNames = map[windowFn]string{
+	winCumSum:    "cum_sum",
+	winRowNumber: "row_number",
+	winRank:      "rank",
+	winDenseRank: "dense_rank",
+	winLag:       "lag",
+	winLead:      "lead",
+	winFirst:     "first",
+	winLast:      "last",
+}
+
+// window holds a window function, and the partitioning and ordering of the rows it is computed over
+type window struct {
+	fn          windowFn
+	param       any // Lag/Lead offset, or Rank/DenseRank direction
+	partitionBy []string
+	orderBy     []ColExpr
+	descending  []bool
+}
+
+// String returns a readable representation of the window function applied to args
+func (w *window) String(args []ColExpr) string {
+	var b strings.Builder
+	switch w.fn {
+	case winAgg, winNone:
+		b.WriteString(args[0].String())
+	default:
+		argStrs := make([]string, 0, len(args)+1)
+		for _, a := range args {
+			argStrs = append(argStrs, a.String())
+		}
+		if w.param != nil {
+			argStrs = append(argStrs, fmt.Sprintf("%v", w.param))
+		}
+		fmt.Fprintf(&b, "%s(%s)", windowFnNames[w.fn], strings.Join(argStrs, ", "))
+	}
+	if len(w.partitionBy) > 0 {
+		fmt.Fprintf(&b, " over (%s)", strings.Join(w.partitionBy, ", "))
+	}
+	if len(w.orderBy) > 0 {
+		orderStrs := make([]string, len(w.orderBy))
+		for i, o := range w.orderBy {
+			orderStrs[i] = o.String()
+			if len(w.descending) > 0 && w.descending[i] {
+				orderStrs[i] += " desc"
+			}
+		}
+		fmt.Fprintf(&b, " order by (%s)", strings.Join(orderStrs, ", "))
+	}
+	return b.String()
+}
+
+// RowNumber returns a window expression evaluating the 1-based position of each row within its partition, as an int64
+func RowNumber() ColExpr {
+	return ColExpr{Name: "row_number", kind: windowExpr, win: &window{fn: winRowNumber}}
+}
+
+// CumSum returns a window expression evaluating the cumulative sum of c's non-null elements within each
+// partition; c must be numeric, and null elements stay null
+func (c ColExpr) CumSum() ColExpr {
+	return c.windowed(winCumSum, nil)
+}
+
+// Rank returns a window expression evaluating the rank of each of c's elements within its partition, as an int64;
+// equal elements share the lowest rank (1, 1, 3), and null elements have a null rank.
+//
+// Rows are ranked by c's values, ascending or descending. With OrderBy, rows are instead ranked by the
+// OrderBy keys in their sort order, and rows with equal keys share a rank; c and descending are then unused,
+// and null keys are ranked like any other value.
+func (c ColExpr) Rank(descending bool) ColExpr {
+	return c.windowed(winRank, descending)
+}
+
+// DenseRank returns a window expression evaluating the rank of each of c's elements within its partition
+// (see Rank), with no gaps between ranks (1, 1, 2)
+func (c ColExpr) DenseRank(descending bool) ColExpr {
+	return c.windowed(winDenseRank, descending)
+}
+
+// Lag returns a window expression evaluating c's element n rows earlier within each partition, or null
+// if there is none
+func (c ColExpr) Lag(n int) ColExpr {
+	return c.windowed(winLag, n)
+}
+
+// Lead returns a window expression evaluating c's element n rows later within each partition, or null
+// if there is none
+func (c ColExpr) Lead(n int) ColExpr {
+	return c.windowed(winLead, n)
+}
+
+// First returns a window expression evaluating c's element at the first row of each partition
+func (c ColExpr) First() ColExpr {
+	return c.windowed(winFirst, nil)
+}
+
+// Last returns a window expression evaluating c's element at the last row of each partition
+func (c ColExpr) Last() ColExpr {
+	return c.windowed(winLast, nil)
+}
+
+// Over partitions the rows of a window function by the values of one or more columns; results are
+// aligned with the original rows. Without Over, a window function treats all rows as one partition.
+//
+// Over may also be applied to an aggregation (e.g. Col("x").Sum().Over("id")), broadcasting the
+// aggregate of each partition to each of its rows.
+func (c ColExpr) Over(partitionBy ...string) ColExpr {
+	e := c.asWindow()
+	e.win.partitionBy = partitionBy
+	return e
+}
+
+// OrderBy orders the rows within each partition of a window function, by one or more key expressions;
+// descending holds the direction of each key, or may be empty to order all keys ascending. Null keys come first.
+//
+// Without OrderBy, rows follow their order in the Frame.
+func (c ColExpr) OrderBy(by []ColExpr, descending []bool) ColExpr {
+	e := c.asWindow()
+	e.win.orderBy, e.win.descending = by, descending
+	return e
+}
+
+func (c ColExpr) windowed(fn windowFn, param any) ColExpr {
+	return ColExpr{Name: c.Name, kind: windowExpr, win: &window{fn: fn, param: param}, args: []ColExpr{c}}
+}
+
+// asWindow returns a window expression with a copy of c's window, wrapping c if it's not a window expression
+func (c ColExpr) asWindow() ColExpr {
+	if c.kind == windowExpr {
+		w := *c.win
+		c.win = &w
+		return c
+	}
+	fn := winNone
+	if c.kind == aggExpr {
+		fn = winAgg
+	}
+	return ColExpr{Name: c.Name, kind: windowExpr, win: &window{fn: fn}, args: []ColExpr{c}}
+}
+
+// windowType type-checks a window expression, returning the DataType it evaluates to
+func (f *Frame) windowType(e ColExpr) (dtype.DataType, error) {
+	w := e.win
+	for _, name := range w.partitionBy {
+		if _, ok := f.NameColMap[name]; !ok {
+			return nil, fmt.Errorf("Column '%s' not recognized", name)
+		}
+	}
+	for _, o := range w.orderBy {
+		if _, err := f.exprType(o); err != nil {
+			return nil, err
+		}
+	}
+	if len(w.descending) != 0 && len(w.descending) != len(w.orderBy) {
+		return nil, fmt.Errorf("%s: got %d sort directions for %d keys", e, len(w.descending), len(w.orderBy))
+	}
+
+	switch w.fn {
+	case winNone:
+		return nil, fmt.Errorf("%s: expected a window function or aggregation", e)
+	case winAgg:
+		return f.aggType(e.args[0])
+	case winRowNumber:
+		return dtype.Int64{}, nil
+	}
+
+	t, err := f.exprType(e.args[0])
+	if err != nil {
+		return nil, err
+	}
+	switch w.fn {
+	case winRank, winDenseRank:
+		return dtype.Int64{}, nil
+	case winCumSum:
+		if !dtype.IsNumeric(t.Type()) {
+			return nil, fmt.Errorf("%s: operand must be numeric, got %v", e, t)
+		}
+	}
+	return t, nil
+}
+
+// evalWindow evaluates a type-checked window expression
+func (f *Frame) evalWindow(e ColExpr) (vector.Vector, error) {
+	w, n := e.win, f.Height()
+
+	// partition ids of each row; all rows form one partition without Over
+	ids, nGroups := make([]int, n), 1
+	if len(w.partitionBy) > 0 {
+		keys := make([]vector.Vector, len(w.partitionBy))
+		for i, name := range w.partitionBy {
+			keys[i] = f.Cols[f.NameColMap[name]].Vec
+		}
+		var firstRows []int
+		var err error
+		if ids, firstRows, err = hashop.GroupIDs(keys); err != nil {
+			return nil, err
+		}
+		nGroups = len(firstRows)
+	}
+
+	if w.fn == winAgg {
+		agg := e.args[0]
+		v, err := f.eval(agg.args[0])
+		if err != nil {
+			return nil, err
+		}
+		res, err := aggregate(agg, v, compute.NewGroups(ids, nGroups))
+		if err != nil {
+			return nil, err
+		}
+		return selop.Take(res, ids)
+	}
+
+	var v vector.Vector
+	if len(e.args) > 0 {
+		var err error
+		if v, err = f.eval(e.args[0]); err != nil {
+			return nil, err
+		}
+	}
+
+	// order of the rows within each partition; without OrderBy, ranks follow the operand's values
+	var order []int
+	var keys []vector.Vector
+	var err error
+	switch {
+	case len(w.orderBy) > 0:
+		keys = make([]vector.Vector, len(w.orderBy))
+		for i, o := range w.orderBy {
+			if keys[i], err = f.eval(o); err != nil {
+				return nil, err
+			}
+		}
+		order, err = sortop.ArgSortMulti(keys, w.descending, false)
+	case w.fn == winRank || w.fn == winDenseRank:
+		order, err = sortop.ArgSort(v, w.param.(bool), true)
+	}
+	if err != nil {
+		return nil, err
+	}
+	groups := compute.NewGroupsInOrder(ids, nGroups, order)
+
+	switch w.fn {
+	case winRowNumber:
+		return winop.RowNumber(groups, n), nil
+	case winRank, winDenseRank:
+		if keys != nil {
+			return winop.RankMulti(keys, groups, w.fn == winDenseRank)
+		}
+		return winop.Rank(v, groups, w.fn == winDenseRank)
+	case winLag:
+		return selop.Take(v, winop.ShiftIndices(groups, n, -w.param.(int)))
+	case winLead:
+		return selop.Take(v, winop.ShiftIndices(groups, n, w.param.(int)))
+	case winFirst:
+		return selop.Take(v, winop.FirstIndices(groups, n))
+	case winLast:
+		return selop.Take(v, winop.LastIndices(groups, n))
+	case winCumSum:
+		return cumSum(v, groups)
+	}
+	return nil, fmt.Errorf("invalid window expression %s", e)
+}
+
+func cumSum(v vector.Vector, g *compute.Groups) (vector.Vector, error) {
+	switch x := v.(type) {
+	case *vector.NumericVector[uint8]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[uint16]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[uint32]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[uint64]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[int8]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[int16]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[int32]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[int64]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[int]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[float32]:
+		return winop.CumSum(x, g), nil
+	case *vector.NumericVector[float64]:
+		return winop.CumSum(x, g), nil
+	}
+	return nil, fmt.Errorf("unsupported operand %v for cum_sum", v.Type())
+}
@@ -3,6 +3,7 @@ package csv
 import (
 	"time"
 
+	"github.com/rhawrami/rok-frame/rok/parse"
 	"github.com/rhawrami/rok-frame/rok/vector"
 )
 
@@ -125,110 +126,20 @@ func bToAMonthDayYearShort(b []byte) parsedRes {
 
 // bToBool converts a byte slice to a boolean type
 func bToBool(b []byte) parsedRes {
-	var res boolRes = boolRes{val: false, isNull: true}
-
-	const bytesInTrue = 4
-	const bytesInFalse = 5
-
-	switch len(b) {
-	case 0:
-		return res
-	case 1:
-		switch b[0] {
-		case 'T', 't':
-			res.val, res.isNull = true, false
-		case 'F', 'f':
-			res.val, res.isNull = false, false
-		default:
-			return res
-		}
-	case bytesInTrue:
-		isTrue := (b[0] == 'T' || b[0] == 't') && b[1] == 'r' && b[2] == 'u' && b[3] == 'e'
-		res.val, res.isNull = isTrue, isTrue != res.isNull
-	case bytesInFalse:
-		isFalse := (b[0] == 'F' || b[0] == 'f') && b[1] == 'a' && b[2] == 'l' && b[3] == 's' && b[4] == 'e'
-		res.val, res.isNull = !isFalse, isFalse != res.isNull
-	default:
-		return res
-	}
-	return res
+	val, ok := parse.Bool(b)
+	return boolRes{val: val, isNull: !ok}
 }
 
 // bToSignedInteger converts a byte slice to signed integer type
 func bToSignedInteger[T signedInteger](b []byte) numericRes[T] {
-	var res numericRes[T] = numericRes[T]{val: 0, isNull: true}
-
-	if len(b) == 0 {
-		return res
-	}
-
-	var sign T = 1
-	if (b[0] == dashChar) || (b[0] == plusChar) {
-		if b[0] == dashChar {
-			sign = -1
-		}
-		b = b[1:]
-	}
-
-	var val T = 0
-	var base T = 10
-	for i := 0; i < len(b); i++ {
-		if !isNumericASCII(b[i]) {
-			return res
-		}
-		val = val*base + T(b[i]-numericASCIILower)
-	}
-
-	res.val, res.isNull = val*sign, false
-
-	return res
+	val, ok := parse.Int[T](b)
+	return numericRes[T]{val: val, isNull: !ok}
 }
 
 // bToFloatingPoint converts a byte slice to floating point type
 func bToFloatingPoint[T floatingPoint](b []byte) numericRes[T] {
-	var res numericRes[T] = numericRes[T]{val: 0, isNull: true}
-
-	if len(b) == 0 {
-		return res
-	}
-
-	var sign T = 1
-	if (b[0] == dashChar) || (b[0] == plusChar) {
-		if b[0] == dashChar {
-			sign = -1
-		}
-		b = b[1:]
-	}
-
-	var whole T = 0
-	var remainder T = 0
-	var base T = 10
-	var remBase T = 0.10
-	var on int = 0
-
-	for on < len(b) {
-		if b[on] == decPntChar {
-			on += 1
-			break
-		}
-		if !isNumericASCII(b[on]) {
-			return res
-		}
-		whole = whole*base + T(b[on]-numericASCIILower)
-		on += 1
-	}
-
-	for on < len(b) {
-		if !isNumericASCII(b[on]) {
-			return res
-		}
-		remainder += remBase * T(b[on]-numericASCIILower)
-		remBase *= 0.10
-		on += 1
-	}
-
-	res.val, res.isNull = sign*(whole+remainder), false
-	return res
+	val, ok := parse.Float[T](b)
+	return numericRes[T]{val: val, isNull: !ok}
 }
 
 type signedInteger interface {
@@ -1,9 +1,10 @@
 package csv
 
 import (
-	"fmt"
 	"io"
 	"os"
+
+	"github.com/rhawrami/rok-frame/rok/dtype"
 )
 
 const (
@@ -108,11 +109,12 @@ func (c *CSVInferrer) Infer(maxRows int, sepChar, newLineChar byte) (*CSVSchema,
 	}
 	cSchemas := make([]*colSchema, len(c.colNames))
 	for i, v := range c.colInferrers {
-		fmt.Printf("%s => %s\n", c.colNames[i], strInferredType(v.predictType()))
+		t := v.predictType()
 		cSchemas[i] = &colSchema{
 			cName:   c.colNames[i],
-			cType:   v.predictType(),
-			cParser: v.predictParser(),
+			cType:   t,
+			cDType:  v.predictDType(t),
+			cParser: v.predictParser(t),
 		}
 	}
 
@@ -188,8 +190,28 @@ type colInferrer struct {
 	nSample   int
 }
 
-func (c *colInferrer) predictParser() func([]byte) parsedRes {
-	switch c.predictType() {
+// predictDType returns the DataType of a column of predicted type t
+func (c *colInferrer) predictDType(t inferredType) dtype.DataType {
+	switch t {
+	case intNum:
+		const i32MaxLen = 10
+		if c.valLenMax < i32MaxLen {
+			return dtype.Int32{}
+		}
+		return dtype.Int64{}
+	case floatNum:
+		return dtype.Float64{}
+	case nYearMonthDay, nMonthDayYear, nDayMonthYear, aMonthDayYearLong, aMonthDayYearShort:
+		return dtype.Date{}
+	case boolean:
+		return dtype.Bool{}
+	}
+	return dtype.String{}
+}
+
+// predictParser returns the parser of a column of predicted type t
+func (c *colInferrer) predictParser(t inferredType) func([]byte) parsedRes {
+	switch t {
 	case intNum:
 		const i32MaxLen = 10
 		if c.valLenMax < i32MaxLen {
@@ -229,10 +251,9 @@ func (c *colInferrer) predictType() inferredType {
 		firstPredShare, secondPredShare float32      = 0, 0
 	)
 
-	// get shares
-	c.tally.normalize(float32(c.nSample))
-
-	for k, kShare := range c.tally {
+	for k, kCount := range c.tally {
+		// share of the sample
+		kShare := kCount / float32(c.nSample)
 		if kShare > secondPredShare {
 			if kShare > firstPredShare {
 				prevFirstPred := firstPred
@@ -349,12 +370,6 @@ func inferType(b []byte) inferredType {
 // inferenceTally keeps a tally of inferred type for a column
 type inferenceTally map[inferredType]float32
 
-func (t inferenceTally) normalize(n float32) {
-	for k, v := range t {
-		t[k] = v / n
-	}
-}
-
 func (t inferenceTally) updateTally(i inferredType) {
 	t[i] += 1
 }
@@ -401,6 +416,7 @@ const (
 	strDefault
 )
 
+// strInferredType returns a readable name of an inferred type; the format, for dates
 func strInferredType(t inferredType) string {
 	switch t {
 	case intNum:
@@ -408,19 +424,19 @@ func strInferredType(t inferredType) string {
 	case floatNum:
 		return "float"
 	case nYearMonthDay:
-		return "nYearMonthDay"
+		return "yyyy-mm-dd"
 	case nMonthDayYear:
-		return "nMonthDayYear"
+		return "mm-dd-yyyy"
 	case nDayMonthYear:
-		return "nDayMonthYear"
+		return "dd-mm-yyyy"
 	case aMonthDayYearLong:
-		return "aMonthDayYearLong"
+		return "January 2, 2006"
 	case aMonthDayYearShort:
-		return "aMonthDayYearShort"
+		return "Jan 2, 2006"
 	case boolean:
-		return "boolean"
+		return "bool"
 	case strDefault:
-		return "strDefault"
+		return "string"
 	}
 	return "other"
 }
@@ -1,11 +1,59 @@
 package csv
 
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/rhawrami/rok-frame/rok/dtype"
+)
+
 type colSchema struct {
 	cName   string
 	cType   inferredType
+	cDType  dtype.DataType
 	cParser func(b []byte) parsedRes
 }
 
+// CSVSchema holds the inferred name, type and parser of each column of a CSV, in order
 type CSVSchema struct {
 	cols []*colSchema
 }
+
+// Schema returns the names and DataTypes of the inferred columns
+func (s *CSVSchema) Schema() dtype.Schema {
+	fields := make(dtype.Schema, len(s.cols))
+	for i, c := range s.cols {
+		fields[i] = dtype.Field{Name: c.cName, DType: c.cDType}
+	}
+	return fields
+}
+
+// String returns the inferred columns as a list of names and types, one column per line, as with
+// dtype.Schema; date columns also show the inferred date format:
+//
+//	CSV schema (2 columns)
+//	  id      int32
+//	  joined  date    (yyyy-mm-dd)
+func (s *CSVSchema) String() string {
+	nameWidth, typeWidth := 0, 0
+	for _, c := range s.cols {
+		nameWidth = max(nameWidth, utf8.RuneCountInString(c.cName))
+		typeWidth = max(typeWidth, len(fmt.Sprint(c.cDType)))
+	}
+
+	word := "columns"
+	if len(s.cols) == 1 {
+		word = "column"
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "CSV schema (%d %s)", len(s.cols), word)
+	for _, c := range s.cols {
+		line := fmt.Sprintf("  %s%s%v", c.cName, strings.Repeat(" ", nameWidth-utf8.RuneCountInString(c.cName)+2), c.cDType)
+		if c.cDType.Type() == dtype.DATE {
+			line += strings.Repeat(" ", typeWidth-len(fmt.Sprint(c.cDType))+2) + "(" + strInferredType(c.cType) + ")"
+		}
+		b.WriteString("\n" + line)
+	}
+	return b.String()
+}
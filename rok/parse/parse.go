@@ -0,0 +1,192 @@
+package parse
+
+import (
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	dashChar byte = '-'
+	plusChar byte = '+'
+
+	numericASCIILower byte = '0'
+	numericASCIIUpper byte = '9'
+
+	secsInOneDay int64 = 60 * 60 * 24
+)
+
+// SignedInteger includes the signed integer types supported by Int
+type SignedInteger interface {
+	int8 | int16 | int32 | int64
+}
+
+// UnsignedInteger includes the unsigned integer types supported by Uint
+type UnsignedInteger interface {
+	uint8 | uint16 | uint32 | uint64
+}
+
+// FloatingPoint includes the floating point types supported by Float
+type FloatingPoint interface {
+	float32 | float64
+}
+
+// Int parses a byte slice into a signed integer of type T
+// e.g. []byte("-4820") => int32(-4820)
+//
+// ok == false in the following cases:
+// - empty slice (e.g., len(b) == 0)
+// - non-numeric byte (excluding a leading positive-negative sign)
+// - the value overflows T
+func Int[T SignedInteger](b []byte) (val T, ok bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+
+	neg := false
+	if (b[0] == dashChar) || (b[0] == plusChar) {
+		neg = b[0] == dashChar
+		b = b[1:]
+		if len(b) == 0 {
+			return 0, false
+		}
+	}
+
+	// accumulate as a negative value; the negative range is one larger than the positive
+	var acc T = 0
+	var base T = 10
+	minVal := minSigned[T]()
+	for i := 0; i < len(b); i++ {
+		if !isNumericASCII(b[i]) {
+			return 0, false
+		}
+		d := T(b[i] - numericASCIILower)
+		if acc < (minVal+d)/base {
+			return 0, false
+		}
+		acc = acc*base - d
+	}
+
+	if !neg {
+		if acc == minVal {
+			return 0, false
+		}
+		return -acc, true
+	}
+	return acc, true
+}
+
+// Uint parses a byte slice into an unsigned integer of type T
+// e.g. []byte("18446744073709551615") => uint64(18446744073709551615)
+//
+// ok == false in the following cases:
+// - empty slice (e.g., len(b) == 0)
+// - non-numeric byte (excluding a leading positive sign)
+// - the value overflows T
+func Uint[T UnsignedInteger](b []byte) (val T, ok bool) {
+	if len(b) > 0 && b[0] == plusChar {
+		b = b[1:]
+	}
+	if len(b) == 0 {
+		return 0, false
+	}
+
+	var acc T = 0
+	var base T = 10
+	maxVal := ^T(0)
+	for i := 0; i < len(b); i++ {
+		if !isNumericASCII(b[i]) {
+			return 0, false
+		}
+		d := T(b[i] - numericASCIILower)
+		if acc > (maxVal-d)/base {
+			return 0, false
+		}
+		acc = acc*base + d
+	}
+	return acc, true
+}
+
+// Float parses a byte slice into a floating point of type T, rounding to the nearest value of T
+// e.g. []byte("+4820.7893") => float64(4820.7893)
+//
+// Accepted are the forms of strconv.ParseFloat: decimals with an optional sign and exponent (e.g. "-1.5e-7"),
+// hexadecimal floats, and case-insensitive "NaN", "Inf" and "Infinity".
+//
+// ok == false in the following cases:
+// - empty slice (e.g., len(b) == 0)
+// - malformed number (e.g., a lone sign or decimal point)
+// - the value overflows T
+func Float[T FloatingPoint](b []byte) (val T, ok bool) {
+	bitSize := 64
+	if _, is32 := any(T(0)).(float32); is32 {
+		bitSize = 32
+	}
+	f, err := strconv.ParseFloat(string(b), bitSize)
+	if err != nil {
+		return 0, false
+	}
+	return T(f), true
+}
+
+// Bool parses a byte slice into a boolean
+//
+// ok == false when the input is not one of the following formats:
+// [`t`, `f`, `T`, `F`, `true`, `false`, `True`, `False`]
+func Bool(b []byte) (val bool, ok bool) {
+	const bytesInTrue = 4
+	const bytesInFalse = 5
+
+	switch len(b) {
+	case 1:
+		switch b[0] {
+		case 'T', 't':
+			return true, true
+		case 'F', 'f':
+			return false, true
+		}
+	case bytesInTrue:
+		isTrue := (b[0] == 'T' || b[0] == 't') && b[1] == 'r' && b[2] == 'u' && b[3] == 'e'
+		return isTrue, isTrue
+	case bytesInFalse:
+		isFalse := (b[0] == 'F' || b[0] == 'f') && b[1] == 'a' && b[2] == 'l' && b[3] == 's' && b[4] == 'e'
+		return false, isFalse
+	}
+	return false, false
+}
+
+// Date parses a byte slice into a date (N days since Unix epoch, stored as int32), given a time.Parse layout;
+// any time of day is dropped, and a date with a zone offset is the calendar date written, in that zone
+//
+// ok == false when the slice is empty, or cannot be parsed with the layout
+func Date(b []byte, layout string) (val int32, ok bool) {
+	if len(b) == 0 {
+		return 0, false
+	}
+
+	d, err := time.Parse(layout, string(b))
+	if err != nil {
+		return 0, false
+	}
+
+	// midnight UTC of the calendar date; a whole number of days from the epoch, before or after it
+	year, month, day := d.Date()
+	return int32(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Unix() / secsInOneDay), true
+}
+
+func minSigned[T SignedInteger]() T {
+	var m int64 = math.MinInt64
+	switch any(T(0)).(type) {
+	case int8:
+		m = math.MinInt8
+	case int16:
+		m = math.MinInt16
+	case int32:
+		m = math.MinInt32
+	}
+	return T(m)
+}
+
+func isNumericASCII(b byte) bool {
+	return b >= numericASCIILower && b <= numericASCIIUpper
+}
@@ -0,0 +1,194 @@
+package parse
+
+import (
+	"math"
+	"testing"
+)
+
+func TestInt(t *testing.T) {
+	tests := []struct {
+		in   string
+		want int64
+		ok   bool
+	}{
+		{"0", 0, true},
+		{"-4820", -4820, true},
+		{"+17", 17, true},
+		{"007", 7, true},
+		{"9223372036854775807", math.MaxInt64, true},
+		{"-9223372036854775808", math.MinInt64, true},
+		{"9223372036854775808", 0, false},
+		{"-9223372036854775809", 0, false},
+		{"", 0, false},
+		{"-", 0, false},
+		{"+", 0, false},
+		{"1.5", 0, false},
+		{"1e5", 0, false},
+		{" 1", 0, false},
+		{"--1", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Int[int64]([]byte(tt.in))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Int[int64](%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	int8Tests := []struct {
+		in   string
+		want int8
+		ok   bool
+	}{
+		{"127", 127, true},
+		{"-128", -128, true},
+		{"128", 0, false},
+		{"-129", 0, false},
+		{"1000", 0, false},
+	}
+	for _, tt := range int8Tests {
+		got, ok := Int[int8]([]byte(tt.in))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Int[int8](%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestUint(t *testing.T) {
+	tests := []struct {
+		in   string
+		want uint64
+		ok   bool
+	}{
+		{"0", 0, true},
+		{"+42", 42, true},
+		{"9223372036854775808", 1 << 63, true},
+		{"18446744073709551615", math.MaxUint64, true},
+		{"18446744073709551616", 0, false},
+		{"99999999999999999999", 0, false},
+		{"-1", 0, false},
+		{"-0", 0, false},
+		{"", 0, false},
+		{"+", 0, false},
+		{"1_000", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Uint[uint64]([]byte(tt.in))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Uint[uint64](%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	uint8Tests := []struct {
+		in   string
+		want uint8
+		ok   bool
+	}{
+		{"255", 255, true},
+		{"256", 0, false},
+		{"260", 0, false},
+	}
+	for _, tt := range uint8Tests {
+		got, ok := Uint[uint8]([]byte(tt.in))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Uint[uint8](%q) = %d, %v; want %d, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestFloat(t *testing.T) {
+	tests := []struct {
+		in   string
+		want float64
+		ok   bool
+	}{
+		{"0.3", 0.3, true},
+		{"-0.0000001", -1e-07, true},
+		{"+4820.7893", 4820.7893, true},
+		{"1e5", 1e5, true},
+		{"1E-5", 1e-5, true},
+		{"-2.5e+3", -2500, true},
+		{".5", 0.5, true},
+		{"5.", 5, true},
+		{"1.7976931348623157e308", math.MaxFloat64, true},
+		{"inf", math.Inf(1), true},
+		{"-Infinity", math.Inf(-1), true},
+		{"1e400", 0, false},
+		{"", 0, false},
+		{"-", 0, false},
+		{".", 0, false},
+		{"1.2.3", 0, false},
+		{"e5", 0, false},
+		{"1e", 0, false},
+		{"abc", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Float[float64]([]byte(tt.in))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Float[float64](%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+
+	if got, ok := Float[float64]([]byte("NaN")); !ok || !math.IsNaN(got) {
+		t.Errorf("Float[float64](\"NaN\") = %v, %v; want NaN, true", got, ok)
+	}
+	if got, ok := Float[float64]([]byte("-0")); !ok || got != 0 || !math.Signbit(got) {
+		t.Errorf("Float[float64](\"-0\") = %v, %v; want -0, true", got, ok)
+	}
+
+	// float32 rounds to its own nearest value, and overflows sooner
+	if got, ok := Float[float32]([]byte("0.1")); !ok || got != float32(0.1) {
+		t.Errorf("Float[float32](\"0.1\") = %v, %v; want 0.1, true", got, ok)
+	}
+	if got, ok := Float[float32]([]byte("1e39")); ok {
+		t.Errorf("Float[float32](\"1e39\") = %v, %v; want overflow", got, ok)
+	}
+}
+
+func TestBool(t *testing.T) {
+	tests := []struct {
+		in       string
+		want, ok bool
+	}{
+		{"t", true, true},
+		{"F", false, true},
+		{"true", true, true},
+		{"True", true, true},
+		{"false", false, true},
+		{"False", false, true},
+		{"TRUE", false, false},
+		{"yes", false, false},
+		{"", false, false},
+	}
+	for _, tt := range tests {
+		got, ok := Bool([]byte(tt.in))
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Bool(%q) = %v, %v; want %v, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestDate(t *testing.T) {
+	tests := []struct {
+		in, layout string
+		want       int32
+		ok         bool
+	}{
+		{"1970-01-01", "2006-01-02", 0, true},
+		{"2000-03-01", "2006-01-02", 11017, true},
+		{"1969-12-31", "2006-01-02", -1, true},
+		{"03/01/2000", "01/02/2006", 11017, true},
+		{"1969-12-31 12:00", "2006-01-02 15:04", -1, true},
+		{"1900-02-28 23:59:59", "2006-01-02 15:04:05", -25509, true},
+		{"1970-01-01 00:30", "2006-01-02 15:04", 0, true},
+		{"1969-12-31 22:00 -0500", "2006-01-02 15:04 -0700", -1, true},
+		{"1970-01-01 01:00 +0200", "2006-01-02 15:04 -0700", 0, true},
+		{"2000-02-30", "2006-01-02", 0, false},
+		{"", "2006-01-02", 0, false},
+	}
+	for _, tt := range tests {
+		got, ok := Date([]byte(tt.in), tt.layout)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("Date(%q, %q) = %d, %v; want %d, %v", tt.in, tt.layout, got, ok, tt.want, tt.ok)
+		}
+	}
+}
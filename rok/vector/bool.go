@@ -66,6 +66,11 @@ func (v *BoolVector) DeepCopy() *BoolVector {
 	}
 }
 
+// Clone returns a deep copy of the vector as a Vector
+func (v *BoolVector) Clone() Vector {
+	return v.DeepCopy()
+}
+
 // BoolVecFromComponenets returns a BoolVector, given data, and a ValidityBitMap
 func BoolVecFromComponenets(dType dtype.DataType, data []byte, validity ValidityBitMap) *BoolVector {
 	return &BoolVector{
@@ -69,6 +69,11 @@ func (v *DateVector) DeepCopy() *DateVector {
 	}
 }
 
+// Clone returns a deep copy of the vector as a Vector
+func (v *DateVector) Clone() Vector {
+	return v.DeepCopy()
+}
+
 // DateVecFromComponents returns a DateVector, given data, and a ValidityBitMap
 func DateVecFromComponents(data []int32, validity ValidityBitMap) *DateVector {
 	return &DateVector{
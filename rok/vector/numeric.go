@@ -72,6 +72,11 @@ func (v *NumericVector[T]) DeepCopy() *NumericVector[T] {
 	}
 }
 
+// Clone returns a deep copy of the vector as a Vector
+func (v *NumericVector[T]) Clone() Vector {
+	return v.DeepCopy()
+}
+
 // NumericVecFromComponents returns a NumericVector, given a Datatype, data, ValidityBitMap
 func NumericVecFromComponents[T Numeric](dType dtype.DataType, data []T, validity ValidityBitMap) *NumericVector[T] {
 	return &NumericVector[T]{
@@ -77,6 +77,11 @@ func (v *StringVector) DeepCopy() *StringVector {
 	}
 }
 
+// Clone returns a deep copy of the vector as a Vector
+func (v *StringVector) Clone() Vector {
+	return v.DeepCopy()
+}
+
 // StringVecFromComponents returns a StringVector, given data, offsets and a ValidityBitMap
 func StringVecFromComponents(data []byte, offsets []int64, validity ValidityBitMap) *StringVector {
 	return &StringVector{
@@ -70,6 +70,26 @@ func (m ValidityBitMap) DeepCopy() ValidityBitMap {
 	}
 }
 
+// NewValidityBitMap returns a ValidityBitMap of trueLen elements, all set to not-null.
+//
+// Bits past trueLen in the final byte are left unset.
+func NewValidityBitMap(trueLen int) ValidityBitMap {
+	lenMap := (trueLen + 7) / 8
+	buff := make([]byte, lenMap)
+	for i := 0; i < trueLen/8; i++ {
+		buff[i] = 0xFF
+	}
+	if rem := trueLen % 8; rem != 0 {
+		buff[lenMap-1] = byte(1)<<rem - 1
+	}
+
+	return ValidityBitMap{
+		TrueLen:   trueLen,
+		NullCount: 0,
+		Buffer:    buff,
+	}
+}
+
 // ValidityBitMapFromBools returns a new ValidityBitMap, taking in a
 // boolean slice as input.
 func ValidityBitMapFromBools(b []bool) ValidityBitMap {
@@ -99,6 +119,16 @@ func ValidityBitMapFromBools(b []bool) ValidityBitMap {
 	}
 }
 
+// ValidityBitMapFromBuff returns a ValidityBitMap over a bitmap buffer of trueLen elements, counting its
+// null elements; bits past trueLen in the final byte must be unset
+func ValidityBitMapFromBuff(b []byte, trueLen int) ValidityBitMap {
+	return ValidityBitMap{
+		TrueLen:   trueLen,
+		NullCount: NullCountFromByteBuff(b, trueLen),
+		Buffer:    b,
+	}
+}
+
 // NullCountFromByteBuff returns the null count from a byte slice, given a true length of the slice
 func NullCountFromByteBuff(b []byte, trueLen int) int {
 	n := trueLen
@@ -8,7 +8,8 @@ type Vector interface {
 	Type() dtype.DataType
 	Len() int
 	NullCount() int
+	Validity() ValidityBitMap
 	IsNull(i int) bool
 	IsNullBinary(i int) uint8
-	DeepCopy() Vector
+	Clone() Vector
 }